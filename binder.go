@@ -2,7 +2,7 @@
 //
 // Binder maps data from HTTP requests to Go structs using struct tags,
 // supporting multiple data sources including path parameters, query strings,
-// request bodies (JSON and form-encoded), and cookies.
+// request bodies (JSON, XML and form-encoded), and cookies.
 //
 // Basic usage:
 //
@@ -19,12 +19,11 @@
 package binder
 
 import (
-	"bytes"
 	"encoding"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"reflect"
 	"strconv"
@@ -38,14 +37,17 @@ const (
 	query  = "query"
 	body   = "body"
 	jjson  = "json"
+	xxml   = "xml"
 	cookie = "cookie"
+	header = "header"
+	bind   = "bind"
 )
 
 // fieldInfo stores cached reflection data for struct fields
 type fieldInfo struct {
 	Index     int
 	FieldType reflect.StructField
-	Source    string // "path", "query", "body", "json", "cookie"
+	Source    string // "bind", "path", "query", "body", "json", "xml", "cookie"
 	TagName   string
 	OmitEmpty bool
 }
@@ -79,18 +81,40 @@ type Validator interface {
 
 // Bind maps data from an HTTP request into a struct using reflection and struct tags.
 //
-// The target must be a pointer to a struct. Bind supports multiple data sources:
+// The target is usually a pointer to a struct. Bind supports multiple data sources:
 //
 //   - path:"name"   - URL path parameters (requires Go 1.22+)
 //   - query:"name"  - URL query parameters
-//   - body:"name"   - Request body (JSON or form-encoded based on Content-Type)
+//   - body:"name"   - Request body (JSON, XML or form-encoded based on Content-Type)
 //   - json:"name"   - Alternative to body tag for JSON data
+//   - xml:"name"    - Alternative to body tag for XML data, supporting "a>b" paths
 //   - cookie:"name" - HTTP cookies
 //
+// A bind tag such as `bind:"header=X-Api-Key,query=api_key"` lists sources
+// in order of precedence, binding from the first one present. Chains may use
+// path, query, header, cookie and body sources. A source without a key,
+// as in `bind:"query"`, derives it from the field name using
+// BindOptions.Naming, and a blank _ struct{} field with a bind tag gives
+// every untagged field of its struct that source.
+//
 // Tag modifiers:
 //
 //   - omitempty - Skip binding if the value is empty
 //
+// A field tagged `body:",raw"` receives the body exactly as received, before
+// decompression or decoding, which is what webhook signatures are computed
+// over. It may be a []byte, string, json.RawMessage or io.Reader.
+//
+// A field tagged `body:"-"` or `body:",whole"` receives the entire decoded
+// body, which for JSON may be an array or scalar rather than an object.
+// A target that is not a struct, such as a pointer to a slice, is bound
+// from the whole body in the same way.
+//
+// time.Time fields are parsed as RFC 3339 unless a layout tag is given, e.g.
+// `layout:"2006-01-02"`, or one of `layout:"unix"`, "unixmilli", "unixmicro"
+// and "unixnano" for Unix timestamps. time.Duration fields accept strings
+// such as "1m30s" as well as plain numbers.
+//
 // Example:
 //
 //	type UpdateUserRequest struct {
@@ -106,23 +130,56 @@ type Validator interface {
 //	}
 //
 // Returns an error if:
-//   - The target is not a pointer to a struct
+//   - The target is not a pointer
 //   - Type conversion fails
 //   - Required fields are missing
 //   - Validation fails (if the struct implements Validator)
 func Bind(r *http.Request, i interface{}) error {
+	return bindWithOptions(r, i, &defaultOptions)
+}
+
+// BindWithOptions behaves like Bind but applies the given options to this call.
+//
+// Example:
+//
+//	opts := binder.BindOptions{
+//	    TimeLocation: time.Local,
+//	    DurationUnit: time.Second,
+//	}
+//	if err := binder.BindWithOptions(r, &req, opts); err != nil {
+//	    // Handle binding error
+//	}
+func BindWithOptions(r *http.Request, i interface{}, opts BindOptions) error {
+	return bindWithOptions(r, i, &opts)
+}
+
+// bindWithOptions implements BindWithOptions. Bind and Binder pass shared
+// options, which exact key matching leaves unwritten, to avoid a copy.
+func bindWithOptions(r *http.Request, i interface{}, opts *BindOptions) error {
 	typ := reflect.TypeOf(i).Elem()
 	val := reflect.ValueOf(i).Elem()
 
 	// Parse request body once
-	bodyData, err := parseRequestBody(r)
+	bodyData, err := parseRequestBody(r, opts)
 	if err != nil {
 		return err
 	}
 
-	// Process each field in the struct
-	if err := bindStructFields(r, typ, val, bodyData); err != nil {
-		return err
+	switch {
+	case typ.Kind() == reflect.Struct && typ != timeType:
+		if opts.RejectUnknownFields {
+			if err := rejectUnknownFields(r, typ, bodyData, opts); err != nil {
+				return err
+			}
+		}
+		if err := bindStructFields(r, typ, val, bodyData, opts); err != nil {
+			return err
+		}
+	case bodyData != nil:
+		// A non-struct target, such as a slice, receives the whole body
+		if err := setField(val, bodyData, "", opts); err != nil {
+			return fmt.Errorf("error binding body: %w", err)
+		}
 	}
 
 	// Run validation if the struct implements Validator
@@ -135,47 +192,92 @@ func Bind(r *http.Request, i interface{}) error {
 	return nil
 }
 
-// parseRequestBody reads and parses the request body, restoring it for other readers
-func parseRequestBody(r *http.Request) (map[string]interface{}, error) {
-	if r.Body == nil || r.ContentLength <= 0 {
-		return make(map[string]interface{}), nil
+// parseRequestBody reads and parses the request body, restoring it for other readers.
+// Bodies of unknown length, such as chunked uploads, are read the same way as
+// bodies with a declared Content-Length. It returns nil when there is no body
+// or the body cannot be decoded.
+func parseRequestBody(r *http.Request, opts *BindOptions) (interface{}, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
 	}
 
-	// Read the body once
-	bodyBytes, err := io.ReadAll(r.Body)
+	// Read the body once, restoring it for other potential readers.
+	// Compressed bodies are restored as received so they still match
+	// their Content-Encoding.
+	body, size, err := bufferBody(r, opts)
 	if err != nil {
-		return nil, fmt.Errorf("error reading request body: %w", err)
+		return nil, err
+	}
+	if size == 0 {
+		return nil, nil
 	}
-
-	// Restore the body for other potential readers
-	r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-	// Create a copy of the request with the new body for parsing
-	rCopy := *r
-	rCopy.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 
 	// Parse the body
-	bodyData, err := parseBody(rCopy)
+	var bodyData interface{}
+	decompressed, err := decompressBody(body, r.Header.Values("Content-Encoding"), opts)
+	if err == nil {
+		bodyData, err = parseBody(r.Header.Get("Content-Type"), decompressed, opts)
+	}
+	if isFatalBodyError(err) {
+		return nil, err
+	}
 	if err != nil {
 		// Continue with empty body - we still want to bind other parameters
 		// The error is non-fatal as data might come from path/query/cookies
-		return make(map[string]interface{}), nil
+		return nil, nil
 	}
 
 	return bodyData, nil
 }
 
+// DefaultMaxBodySize is the request body size limit applied when
+// BindOptions.MaxBodySize is zero.
+const DefaultMaxBodySize = 10 << 20
+
+// ErrBodyTooLarge is returned when a request body exceeds the configured
+// size limit. Handlers will usually map it to 413 Content Too Large:
+//
+//	if errors.Is(err, binder.ErrBodyTooLarge) {
+//	    http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+//	    return
+//	}
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// isFatalBodyError reports whether a body error must fail the bind rather
+// than being treated as an empty body
+func isFatalBodyError(err error) bool {
+	return errors.Is(err, ErrUnsupportedMediaType) ||
+		errors.Is(err, ErrInvalidUTF8) ||
+		errors.Is(err, ErrDecompressedBodyTooLarge) ||
+		errors.Is(err, ErrBodyTooDeep) ||
+		errors.Is(err, ErrArrayTooLong) ||
+		errors.Is(err, ErrTooManyKeys)
+}
+
 // bindStructFields processes each field in the struct and binds data from the request
-func bindStructFields(r *http.Request, typ reflect.Type, val reflect.Value, bodyData map[string]interface{}) error {
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
+func bindStructFields(r *http.Request, typ reflect.Type, val reflect.Value, bodyData interface{}, opts *BindOptions) error {
+	presence := findPresence(typ, val)
+	for i, field := range structFields(typ, opts.Tags) {
 		fieldVal := val.Field(i)
 
+		// Raw body fields take the bytes as received rather than decoded data
+		if isRawBodyTag(field.Tag.Get(body)) {
+			if err := bindRawBody(r, fieldVal, opts); err != nil {
+				return fmt.Errorf("error setting field %s: %w", field.Name, err)
+			}
+			continue
+		}
+
 		// Extract value from appropriate source
-		value, exists, err := extractFieldValue(r, field, bodyData)
+		value, from, err := extractFieldValue(r, field, bodyData, opts)
 		if err != nil {
 			return err
 		}
+		exists := from.Source != ""
+		if exists && presence != nil && !isWholeBodyTag(field.Tag.Get(body)) {
+			presence.add(from.Name, value)
+			presence.setSource(field.Name, from.Source)
+		}
 
 		// Skip if value doesn't exist or should be omitted
 		if !exists || shouldOmitField(field, value) {
@@ -183,80 +285,116 @@ func bindStructFields(r *http.Request, typ reflect.Type, val reflect.Value, body
 		}
 
 		// Set the field value
-		if err := bindFieldValue(fieldVal, value, field.Name); err != nil {
+		if err := bindFieldValue(fieldVal, value, field, opts); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-// extractFieldValue gets the value for a field from the appropriate request source
-func extractFieldValue(r *http.Request, field reflect.StructField, bodyData map[string]interface{}) (interface{}, bool, error) {
+// extractFieldValue gets the value for a field from the appropriate request
+// source. It reports the source and key the value came from, with an empty
+// Source if the field is absent from the request.
+func extractFieldValue(r *http.Request, field reflect.StructField, bodyData interface{}, opts *BindOptions) (interface{}, sourceRef, error) {
 	tag := field.Tag
 	pathTag := tag.Get(path)
 	queryTag := tag.Get(query)
 	bodyTag := tag.Get(body)
 	jsonTag := tag.Get(jjson)
+	xmlTag := tag.Get(xxml)
 	cookieTag := tag.Get(cookie)
 
 	switch {
+	case tag.Get(bind) != "":
+		return extractChainValue(r, field, bodyData, opts)
+
 	case pathTag != "":
-		v := r.PathValue(pathTag)
-		return v, v != "", nil
+		v, from := lookupSource(r, field, sourceRef{path, pathTag}, bodyData, opts)
+		return v, from, nil
 
 	case queryTag != "":
-		paramName := queryTag
-		if commaIndex := strings.Index(paramName, ","); commaIndex != -1 {
-			paramName = paramName[:commaIndex]
-		}
-		v := r.URL.Query().Get(paramName)
-		return v, v != "", nil
+		v, from := lookupSource(r, field, sourceRef{query, tagName(queryTag)}, bodyData, opts)
+		return v, from, nil
 
-	case bodyTag != "":
-		v, exists := bodyData[bodyTag]
-		return v, exists, nil
+	case isWholeBodyTag(bodyTag):
+		if bodyData == nil {
+			return nil, sourceRef{}, nil
+		}
+		return bodyData, sourceRef{body, ""}, nil
 
-	case jsonTag != "":
-		v, exists := bodyData[jsonTag]
-		return v, exists, nil
+	case bodyTag != "" || jsonTag != "" || xmlTag != "":
+		fields, _ := bodyData.(map[string]interface{})
+		if v, exists := lookupBodyField(fields, field, opts); exists {
+			return v, sourceRef{body, wireKey(field)}, nil
+		}
+		return nil, sourceRef{}, nil
 
 	case cookieTag != "":
-		c, err := r.Cookie(cookieTag)
-		if err == nil {
-			return c.Value, true, nil
-		}
-		return nil, false, nil
+		v, from := lookupSource(r, field, sourceRef{cookie, cookieTag}, bodyData, opts)
+		return v, from, nil
 
 	default:
-		return nil, false, nil
+		return nil, sourceRef{}, nil
 	}
 }
 
-// shouldOmitField determines if a field should be skipped based on omitempty
+// findPresence returns the Presence field of a struct, reset for this bind,
+// or nil if it has none
+func findPresence(typ reflect.Type, val reflect.Value) *Presence {
+	for i := 0; i < typ.NumField(); i++ {
+		if f := typ.Field(i); f.Type == presenceType && f.IsExported() {
+			p := val.Field(i).Addr().Interface().(*Presence)
+			*p = Presence{}
+			return p
+		}
+	}
+	return nil
+}
+
+// wireKey returns the request key a field binds from, using the tag
+// extractFieldValue and lookupBodyField would use
+func wireKey(field reflect.StructField) string {
+	for _, source := range []string{path, query, body, jjson, xxml, cookie} {
+		if name := tagName(field.Tag.Get(source)); name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// shouldOmitField determines if a field should be skipped based on omitempty.
+// Optional fields are never skipped, as they record an empty or null value.
 func shouldOmitField(field reflect.StructField, value interface{}) bool {
 	tag := field.Tag
-	omitEmpty := strings.Contains(tag.Get(path)+tag.Get(query)+tag.Get(body)+tag.Get(jjson)+tag.Get(cookie), "omitempty")
-	return omitEmpty && isEmptyValue(value)
+	omitEmpty := strings.Contains(tag.Get(bind)+tag.Get(path)+tag.Get(query)+tag.Get(body)+tag.Get(jjson)+tag.Get(xxml)+tag.Get(cookie), "omitempty")
+	return omitEmpty && isEmptyValue(value) && !isOptionalType(field.Type)
 }
 
 // bindFieldValue sets the value on a struct field, handling nested structs and pointers
-func bindFieldValue(fieldVal reflect.Value, value interface{}, fieldName string) error {
-	if fieldVal.Kind() == reflect.Ptr && fieldVal.IsNil() {
-		fieldVal.Set(reflect.New(fieldVal.Type().Elem())) // Initialize pointer fields
+func bindFieldValue(fieldVal reflect.Value, value interface{}, field reflect.StructField, opts *BindOptions) error {
+	if _, ok := asOptional(fieldVal); ok {
+		if err := setField(fieldVal, value, field.Tag, opts); err != nil {
+			return fmt.Errorf("error setting field %s: %w", field.Name, err)
+		}
+		return nil
 	}
 
 	// Handle nested structs recursively
-	if fieldVal.Kind() == reflect.Struct || (fieldVal.Kind() == reflect.Ptr && fieldVal.Elem().Kind() == reflect.Struct) {
+	structType := fieldVal.Type()
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() == reflect.Struct {
 		if nestedMap, ok := value.(map[string]interface{}); ok {
-			if err := BindStruct(fieldVal, nestedMap); err != nil {
-				return fmt.Errorf("error binding nested field %s: %w", fieldName, err)
+			if err := bindStruct(fieldVal, nestedMap, opts); err != nil {
+				return fmt.Errorf("error binding nested field %s: %w", field.Name, err)
 			}
 			return nil
 		}
 	}
 
-	if err := setField(fieldVal, value); err != nil {
-		return fmt.Errorf("error setting field %s: %w", fieldName, err)
+	if err := setField(fieldVal, value, field.Tag, opts); err != nil {
+		return fmt.Errorf("error setting field %s: %w", field.Name, err)
 	}
 	return nil
 }
@@ -280,15 +418,30 @@ func getFieldInfo(typ reflect.Type) map[string]fieldInfo {
 		return info
 	}
 
-	info = make(map[string]fieldInfo)
-	for i := 0; i < typ.NumField(); i++ {
-		field := typ.Field(i)
+	info = buildFieldInfo(typ, TagNames{})
+	fieldCache[typ] = info
+	return info
+}
+
+// buildFieldInfo collects field information for a struct type as seen with
+// the given tag names
+func buildFieldInfo(typ reflect.Type, tags TagNames) map[string]fieldInfo {
+	info := make(map[string]fieldInfo)
+	for i, field := range structFields(typ, tags) {
 		fi := fieldInfo{
 			Index:     i,
 			FieldType: field,
 		}
 
 		// Check each tag type
+		if tag := field.Tag.Get(bind); tag != "" {
+			fi.Source = bind
+			fi.TagName = tag
+			fi.OmitEmpty = strings.Contains(tag, "omitempty")
+			info[field.Name] = fi
+			continue
+		}
+
 		if tag := field.Tag.Get(path); tag != "" {
 			fi.Source = path
 			fi.TagName = tag
@@ -321,6 +474,14 @@ func getFieldInfo(typ reflect.Type) map[string]fieldInfo {
 			continue
 		}
 
+		if tag := field.Tag.Get(xxml); tag != "" {
+			fi.Source = xxml
+			fi.TagName = tag
+			fi.OmitEmpty = strings.Contains(tag, "omitempty")
+			info[field.Name] = fi
+			continue
+		}
+
 		if tag := field.Tag.Get(cookie); tag != "" {
 			fi.Source = cookie
 			fi.TagName = tag
@@ -330,7 +491,6 @@ func getFieldInfo(typ reflect.Type) map[string]fieldInfo {
 		}
 	}
 
-	fieldCache[typ] = info
 	return info
 }
 
@@ -346,6 +506,11 @@ func getFieldInfo(typ reflect.Type) map[string]fieldInfo {
 // The function handles both pointer and non-pointer fields, automatically
 // initializing nil pointers as needed.
 func BindStruct(field reflect.Value, data map[string]interface{}) error {
+	return bindStruct(field, data, &defaultOptions)
+}
+
+// bindStruct implements BindStruct using the options of the current bind
+func bindStruct(field reflect.Value, data map[string]interface{}, opts *BindOptions) error {
 	target := field
 	if field.Kind() == reflect.Ptr {
 		if field.IsNil() {
@@ -354,92 +519,206 @@ func BindStruct(field reflect.Value, data map[string]interface{}) error {
 		target = field.Elem()
 	}
 
-	typ := target.Type()
-	for i := 0; i < typ.NumField(); i++ {
-		fieldType := typ.Field(i)
-		tag := fieldType.Tag.Get(body)
-		if tag == "" {
-			tag = fieldType.Tag.Get(jjson)
-		}
-		if tag == "" {
-			continue
-		}
-
-		nestedValue, ok := data[tag]
+	for i, fieldType := range structFields(target.Type(), opts.Tags) {
+		nestedValue, ok := lookupBodyField(data, fieldType, opts)
 		if !ok {
 			continue
 		}
 
 		nestedField := target.Field(i)
-		if nestedField.Kind() == reflect.Ptr && nestedField.IsNil() {
-			nestedField.Set(reflect.New(nestedField.Type().Elem()))
-		}
-
-		if err := setField(nestedField, nestedValue); err != nil {
+		if err := setField(nestedField, nestedValue, fieldType.Tag, opts); err != nil {
 			return fmt.Errorf("error setting nested field %s: %w", fieldType.Name, err)
 		}
 	}
 	return nil
 }
 
+// lookupBodyField finds the value for a struct field in decoded body data
+// using the body and query entries of its bind chain, or else its body,
+// json, xml or query tag, in that order
+func lookupBodyField(data map[string]interface{}, field reflect.StructField, opts *BindOptions) (interface{}, bool) {
+	if field.Tag.Get(bind) != "" {
+		return lookupChainField(data, field, opts)
+	}
+	if tag := field.Tag.Get(body); isWholeBodyTag(tag) || isRawBodyTag(tag) {
+		return nil, false
+	}
+	if name := tagName(field.Tag.Get(body)); name != "" {
+		return lookupKey(data, name, opts)
+	}
+	if name := tagName(field.Tag.Get(jjson)); name != "" {
+		return lookupKey(data, name, opts)
+	}
+	if tag := field.Tag.Get(xxml); tag != "" {
+		return lookupXMLField(data, tag, field.Name, opts)
+	}
+	if name := tagName(field.Tag.Get(query)); name != "" {
+		// Fields of structs bound from nested query keys
+		return lookupKey(data, name, opts)
+	}
+	return nil, false
+}
+
+// isWholeBodyTag reports whether a body tag asks for the entire decoded
+// body, as `body:"-"` and `body:",whole"` do
+func isWholeBodyTag(tag string) bool {
+	return tag == "-" || hasTagOption(tag, "whole")
+}
+
+// isRawBodyTag reports whether a body tag asks for the raw body bytes, as
+// `body:",raw"` does
+func isRawBodyTag(tag string) bool {
+	return hasTagOption(tag, "raw")
+}
+
+// hasTagOption reports whether a tag value lists the given option after
+// its name, as in `body:"name,option"`
+func hasTagOption(tag, option string) bool {
+	_, opts, found := strings.Cut(tag, ",")
+	for found {
+		var opt string
+		opt, opts, found = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// tagName returns the name part of a tag value, dropping options such as omitempty
+func tagName(tag string) string {
+	if commaIndex := strings.Index(tag, ","); commaIndex != -1 {
+		return tag[:commaIndex]
+	}
+	return tag
+}
+
 // parseContentType extracts the content type from the Content-Type header
 func parseContentType(header string) string {
+	mediaType, _ := parseMediaType(header)
+	return mediaType
+}
+
+// parseMediaType splits a Content-Type header into its lower-cased media
+// type and its parameters, keyed by lower-cased name
+func parseMediaType(header string) (string, map[string]string) {
+	var mediaType string
+	params := make(map[string]string)
 	for _, part := range strings.Split(header, ";") {
 		part = strings.TrimSpace(part)
-		if !strings.Contains(part, "=") {
-			return strings.ToLower(part)
+		key, value, isParam := strings.Cut(part, "=")
+		if !isParam {
+			if mediaType == "" {
+				mediaType = strings.ToLower(part)
+			}
+			continue
 		}
+		params[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
 	}
-	return ""
+	return mediaType, params
 }
 
-// parseBody extracts and parses the request body into a map
-func parseBody(r http.Request) (map[string]interface{}, error) {
-	var reqBody map[string]interface{}
-	ct := parseContentType(r.Header.Get("Content-Type"))
+// parseBody decodes the request body using the decoder registered for its
+// Content-Type, converting it to UTF-8 according to its charset. Objects
+// decode to a map; JSON bodies may also be arrays or scalars.
+func parseBody(contentType string, body io.Reader, opts *BindOptions) (interface{}, error) {
+	ct, params := parseMediaType(contentType)
 
-	switch ct {
-	case "application/json":
-		err := json.NewDecoder(r.Body).Decode(&reqBody)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode JSON body: %w", err)
+	decoder, ok := lookupDecoder(ct)
+	if !ok {
+		if opts.RejectUnknownMediaType {
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, ct)
 		}
-		return reqBody, nil
+		return nil, nil
+	}
 
-	case "application/x-www-form-urlencoded":
-		reqBody = make(map[string]interface{})
-		err := r.ParseForm()
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse form data: %w", err)
-		}
-		for k, v := range r.PostForm {
-			if len(v) == 1 {
-				reqBody[k] = v[0]
-			} else {
-				reqBody[k] = v
+	label := params["charset"]
+	cs, ok := lookupCharset(label)
+	if !ok && opts.StrictCharset {
+		return nil, fmt.Errorf("%w: charset %q", ErrUnsupportedMediaType, label)
+	}
+
+	var reqBody interface{}
+	var fields map[string]interface{}
+	var err error
+	if cd, ok := decoder.(charsetDecoder); ok {
+		fields, err = cd.decodeCharset(body, label)
+	} else {
+		// UTF-8 bodies are decoded as they are read; others are converted first
+		if cs != charsetUTF8 || opts.StrictCharset {
+			if body, err = toUTF8Reader(body, cs, opts.StrictCharset); err != nil {
+				if isFatalBodyError(err) {
+					return nil, err
+				}
+				return nil, fmt.Errorf("failed to decode %s body: %w", ct, err)
 			}
 		}
-		return reqBody, nil
+		if vd, ok := decoder.(valueDecoder); ok {
+			reqBody, err = vd.decodeValue(body, opts)
+		} else {
+			fields, err = decoder.Decode(body)
+		}
+	}
+	if fields != nil {
+		reqBody = fields
+	}
+	if isFatalBodyError(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s body: %w", ct, err)
+	}
+
+	if opts.hasStructuralLimits() {
+		if err := checkLimits(reqBody, opts, 1); err != nil {
+			return nil, err
+		}
 	}
 
-	return make(map[string]interface{}), nil
+	if opts.StrictCharset {
+		if err := checkUTF8(reqBody); err != nil {
+			return nil, err
+		}
+	}
+	return reqBody, nil
 }
 
-// setField sets the appropriate value on the given reflect.Value field
-func setField(field reflect.Value, value interface{}) error {
-	// Handle nil value
+// setField sets the appropriate value on the given reflect.Value field.
+// The tag is the struct tag of the field being bound and is consulted for
+// per-field conversion settings such as time layouts.
+func setField(field reflect.Value, value interface{}, tag reflect.StructTag, opts *BindOptions) error {
+	// Optional fields record presence, including an explicit null
+	if o, ok := asOptional(field); ok {
+		return o.setOptional(value, func(elem reflect.Value, v interface{}) error {
+			return setField(elem, v, tag, opts)
+		})
+	}
+
+	// Handle explicit null
 	if value == nil {
-		return nil
+		return setNull(field, opts)
+	}
+
+	// Pointers are allocated only once a value has converted successfully
+	if field.Kind() == reflect.Ptr {
+		return setPointer(field, value, tag, opts)
+	}
+
+	// Handle time.Time and time.Duration before TextUnmarshaler so that
+	// layouts and numeric durations are honoured
+	handled, err := trySetTime(field, value, tag, opts)
+	if handled {
+		return err
 	}
 
 	// Handle TextUnmarshaler interface
-	handled, err := tryTextUnmarshaler(field, value)
+	handled, err = tryTextUnmarshaler(field, value)
 	if handled {
 		return err
 	}
 
 	// Handle based on field kind
-	return setFieldByKind(field, value)
+	return setFieldByKind(field, value, tag, opts)
 }
 
 // tryTextUnmarshaler attempts to use TextUnmarshaler interface if implemented
@@ -465,43 +744,82 @@ func tryTextUnmarshaler(field reflect.Value, value interface{}) (bool, error) {
 }
 
 // setFieldByKind sets the field value based on its reflect.Kind
-func setFieldByKind(field reflect.Value, value interface{}) error {
+func setFieldByKind(field reflect.Value, value interface{}, tag reflect.StructTag, opts *BindOptions) error {
 	switch field.Kind() {
 	case reflect.String:
 		return setString(field, value)
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return setInt(field, value)
+		return setInt(field, value, opts)
 
 	case reflect.Float32, reflect.Float64:
 		return setFloat(field, value)
 
 	case reflect.Bool:
-		return setBool(field, value)
+		return setBool(field, value, opts)
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return setUint(field, value)
+		return setUint(field, value, opts)
 
 	case reflect.Slice:
-		return setSlice(field, value)
+		return setSlice(field, value, tag, opts)
 
 	case reflect.Array:
 		return fmt.Errorf("arrays are not supported, use slices instead")
 
 	case reflect.Struct:
-		return setStruct(field, value)
+		return setStruct(field, value, opts)
 
-	case reflect.Ptr:
-		if field.IsNil() {
-			field.Set(reflect.New(field.Type().Elem()))
+	case reflect.Map:
+		return setMap(field, value, tag, opts)
+
+	case reflect.Interface:
+		if field.NumMethod() != 0 {
+			return fmt.Errorf("unsupported type: %s", field.Type())
 		}
-		return setField(field.Elem(), value)
+		field.Set(reflect.ValueOf(value))
+		return nil
+
+	case reflect.Ptr:
+		return setPointer(field, value, tag, opts)
 
 	default:
 		return fmt.Errorf("unsupported type: %s", field.Kind())
 	}
 }
 
+// setNull applies an explicit null. Pointers, slices, maps and interfaces
+// become nil; other fields follow BindOptions.NullValues.
+func setNull(field reflect.Value, opts *BindOptions) error {
+	switch field.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	switch opts.NullValues {
+	case NullZero:
+		field.Set(reflect.Zero(field.Type()))
+	case NullReject:
+		return ErrNullValue
+	}
+	return nil
+}
+
+// setPointer sets the value a pointer field points to, allocating it only
+// if the conversion succeeds
+func setPointer(field reflect.Value, value interface{}, tag reflect.StructTag, opts *BindOptions) error {
+	if !field.IsNil() {
+		return setField(field.Elem(), value, tag, opts)
+	}
+	ptr := reflect.New(field.Type().Elem())
+	if err := setField(ptr.Elem(), value, tag, opts); err != nil {
+		return err
+	}
+	field.Set(ptr)
+	return nil
+}
+
 // setString sets a string value to a field
 func setString(field reflect.Value, value interface{}) error {
 	str, err := toString(value)
@@ -526,77 +844,164 @@ func toString(value interface{}) (string, error) {
 	}
 }
 
-// setInt sets an integer value to a field
-func setInt(field reflect.Value, value interface{}) error {
+// setInt sets an integer value to a field, rejecting values that do not fit
+// the field's bit size or that would lose a fractional part
+func setInt(field reflect.Value, value interface{}, opts *BindOptions) error {
+	var i int64
 	switch v := value.(type) {
 	case int:
-		field.SetInt(int64(v))
+		i = int64(v)
 	case int8:
-		field.SetInt(int64(v))
+		i = int64(v)
 	case int16:
-		field.SetInt(int64(v))
+		i = int64(v)
 	case int32:
-		field.SetInt(int64(v))
+		i = int64(v)
 	case int64:
-		field.SetInt(v)
+		i = v
 	case float32:
-		field.SetInt(int64(v))
+		n, err := floatToInt(float64(v))
+		if err != nil {
+			return err
+		}
+		i = n
 	case float64:
-		field.SetInt(int64(v))
+		n, err := floatToInt(v)
+		if err != nil {
+			return err
+		}
+		i = n
 	case string:
-		i, err := strconv.ParseInt(v, 10, 64)
+		n, err := parseInt(v, opts)
 		if err != nil {
 			return err
 		}
-		field.SetInt(i)
+		i = n
 	default:
 		return fmt.Errorf("cannot convert %T to int", value)
 	}
+
+	if field.OverflowInt(i) {
+		min, max := intBounds(field.Type())
+		return fmt.Errorf("value %d overflows %s (range %d to %d)", i, field.Type(), min, max)
+	}
+	field.SetInt(i)
 	return nil
 }
 
-// setUint sets an unsigned integer value to a field
-func setUint(field reflect.Value, value interface{}) error {
+// setUint sets an unsigned integer value to a field, rejecting negative,
+// fractional and out of range values
+func setUint(field reflect.Value, value interface{}, opts *BindOptions) error {
+	var u uint64
 	switch v := value.(type) {
 	case uint:
-		field.SetUint(uint64(v))
+		u = uint64(v)
 	case uint8:
-		field.SetUint(uint64(v))
+		u = uint64(v)
 	case uint16:
-		field.SetUint(uint64(v))
+		u = uint64(v)
 	case uint32:
-		field.SetUint(uint64(v))
+		u = uint64(v)
 	case uint64:
-		field.SetUint(v)
-	case int:
-		if v < 0 {
+		u = v
+	case int, int8, int16, int32, int64:
+		n := reflect.ValueOf(v).Int()
+		if n < 0 {
 			return fmt.Errorf("cannot convert negative int to uint")
 		}
-		field.SetUint(uint64(v))
+		u = uint64(n)
+	case float32:
+		n, err := floatToUint(float64(v))
+		if err != nil {
+			return err
+		}
+		u = n
 	case float64:
-		if v < 0 {
-			return fmt.Errorf("cannot convert negative float to uint")
+		n, err := floatToUint(v)
+		if err != nil {
+			return err
 		}
-		field.SetUint(uint64(v))
+		u = n
 	case string:
-		i, err := strconv.ParseUint(v, 10, 64)
+		n, err := parseUint(v, opts)
 		if err != nil {
 			return err
 		}
-		field.SetUint(i)
+		u = n
 	default:
 		return fmt.Errorf("cannot convert %T to uint", value)
 	}
+
+	if field.OverflowUint(u) {
+		return fmt.Errorf("value %d overflows %s (range 0 to %d)", u, field.Type(), uintMax(field.Type()))
+	}
+	field.SetUint(u)
 	return nil
 }
 
+// parseInt parses an integer string using the numeric formats enabled in opts
+func parseInt(s string, opts *BindOptions) (int64, error) {
+	s, base, err := opts.numberFormat(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, base, 64)
+}
+
+// parseUint parses an unsigned integer string using the numeric formats enabled in opts
+func parseUint(s string, opts *BindOptions) (uint64, error) {
+	s, base, err := opts.numberFormat(s)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, base, 64)
+}
+
+// floatToInt converts a float to an int64, failing if it has a fractional
+// part or lies outside the int64 range
+func floatToInt(f float64) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("cannot convert fractional value %v to int", f)
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("value %v overflows int64 (range %d to %d)", f, int64(math.MinInt64), int64(math.MaxInt64))
+	}
+	return int64(f), nil
+}
+
+// floatToUint converts a float to a uint64, failing if it is negative, has a
+// fractional part or lies outside the uint64 range
+func floatToUint(f float64) (uint64, error) {
+	if f < 0 {
+		return 0, fmt.Errorf("cannot convert negative float to uint")
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("cannot convert fractional value %v to uint", f)
+	}
+	if f >= math.MaxUint64 {
+		return 0, fmt.Errorf("value %v overflows uint64 (range 0 to %d)", f, uint64(math.MaxUint64))
+	}
+	return uint64(f), nil
+}
+
+// intBounds returns the smallest and largest values of a signed integer type
+func intBounds(t reflect.Type) (int64, int64) {
+	max := int64(1)<<(t.Bits()-1) - 1
+	return -max - 1, max
+}
+
+// uintMax returns the largest value of an unsigned integer type
+func uintMax(t reflect.Type) uint64 {
+	return math.MaxUint64 >> (64 - t.Bits())
+}
+
 // setBool sets a boolean value to a field
-func setBool(field reflect.Value, value interface{}) error {
+func setBool(field reflect.Value, value interface{}, opts *BindOptions) error {
 	switch v := value.(type) {
 	case bool:
 		field.SetBool(v)
 	case string:
-		b, err := strconv.ParseBool(v)
+		b, err := opts.parseBool(v)
 		if err != nil {
 			return err
 		}
@@ -611,44 +1016,49 @@ func setBool(field reflect.Value, value interface{}) error {
 	return nil
 }
 
-// setFloat sets a floating point value to a field
+// setFloat sets a floating point value to a field, rejecting values that
+// exceed the range of a float32 field
 func setFloat(field reflect.Value, value interface{}) error {
+	var f float64
 	switch v := value.(type) {
 	case float32:
-		field.SetFloat(float64(v))
+		f = float64(v)
 	case float64:
-		field.SetFloat(v)
+		f = v
 	case int, int8, int16, int32, int64:
 		// Use reflection to get the actual int value
 		val := reflect.ValueOf(v)
-		field.SetFloat(float64(val.Int()))
+		f = float64(val.Int())
 	case string:
-		f, err := strconv.ParseFloat(v, 64)
+		n, err := strconv.ParseFloat(v, 64)
 		if err != nil {
 			return err
 		}
-		field.SetFloat(f)
+		f = n
 	default:
 		return fmt.Errorf("cannot convert %T to float", value)
 	}
+
+	if field.OverflowFloat(f) {
+		return fmt.Errorf("value %v overflows %s (range ±%v)", f, field.Type(), math.MaxFloat32)
+	}
+	field.SetFloat(f)
 	return nil
 }
 
 // setSlice sets a slice value to a field
-func setSlice(field reflect.Value, value interface{}) error {
+func setSlice(field reflect.Value, value interface{}, tag reflect.StructTag, opts *BindOptions) error {
 	if v, ok := value.([]interface{}); ok {
+		if err := opts.checkArrayLength(len(v)); err != nil {
+			return err
+		}
+
 		// Create a new slice with the same type as the field
 		s := reflect.MakeSlice(field.Type(), len(v), len(v))
 
 		// Set each element in the slice
 		for i := 0; i < len(v); i++ {
-			elem := s.Index(i)
-			if elem.Kind() == reflect.Ptr {
-				elem.Set(reflect.New(elem.Type().Elem()))
-				elem = elem.Elem()
-			}
-
-			if err := setField(elem, v[i]); err != nil {
+			if err := setField(s.Index(i), v[i], tag, opts); err != nil {
 				return fmt.Errorf("error setting slice element at index %d: %w", i, err)
 			}
 		}
@@ -656,38 +1066,32 @@ func setSlice(field reflect.Value, value interface{}) error {
 		return nil
 	}
 
-	// Handle single value that should be converted to a slice
-	if field.Type().Elem().Kind() == reflect.String {
-		if strVal, ok := value.(string); ok {
-			// It's a single string for a string slice
-			s := reflect.MakeSlice(field.Type(), 1, 1)
-			s.Index(0).SetString(strVal)
-			field.Set(s)
-			return nil
-		}
+	// Strings bind to byte slices as their raw bytes
+	if str, ok := value.(string); ok && field.Type().Elem().Kind() == reflect.Uint8 {
+		field.SetBytes([]byte(str))
+		return nil
 	}
 
-	return fmt.Errorf("cannot convert %T to slice", value)
+	// Handle a single value that should be converted to a slice, such as
+	// one query parameter or a lone repeated XML element
+	s := reflect.MakeSlice(field.Type(), 1, 1)
+	if err := setField(s.Index(0), value, tag, opts); err != nil {
+		return fmt.Errorf("cannot convert %T to slice: %w", value, err)
+	}
+	field.Set(s)
+	return nil
 }
 
 // setStruct sets a struct value to a field
-func setStruct(field reflect.Value, value interface{}) error {
+func setStruct(field reflect.Value, value interface{}, opts *BindOptions) error {
 	// Handle map to struct conversion
 	if structMap, ok := value.(map[string]interface{}); ok {
-		for x := 0; x < field.NumField(); x++ {
+		for x, nestedStructType := range structFields(field.Type(), opts.Tags) {
 			nestedField := field.Field(x)
-			nestedStructType := field.Type().Field(x)
-
-			tagValue := nestedStructType.Tag.Get(body)
-			if tagValue == "" {
-				tagValue = nestedStructType.Tag.Get(jjson)
-			}
 
-			if tagValue != "" {
-				if nestedVal, exists := structMap[tagValue]; exists {
-					if err := setField(nestedField, nestedVal); err != nil {
-						return fmt.Errorf("error setting nested field '%s': %w", nestedStructType.Name, err)
-					}
+			if nestedVal, exists := lookupBodyField(structMap, nestedStructType, opts); exists {
+				if err := setField(nestedField, nestedVal, nestedStructType.Tag, opts); err != nil {
+					return fmt.Errorf("error setting nested field '%s': %w", nestedStructType.Name, err)
 				}
 			}
 		}
@@ -700,6 +1104,41 @@ func setStruct(field reflect.Value, value interface{}) error {
 	return fmt.Errorf("cannot set struct field with value of type %T", value)
 }
 
+// setMap sets a map with string keys from decoded object data, converting
+// each value to the map's element type
+func setMap(field reflect.Value, value interface{}, tag reflect.StructTag, opts *BindOptions) error {
+	if field.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("unsupported map key type: %s", field.Type().Key())
+	}
+	data, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("cannot set map field with value of type %T", value)
+	}
+	if err := opts.checkKeys(len(data)); err != nil {
+		return err
+	}
+
+	m := reflect.MakeMapWithSize(field.Type(), len(data))
+	elemType := field.Type().Elem()
+	for k, v := range data {
+		elem := reflect.New(elemType).Elem()
+		if err := setField(elem, v, tag, opts); err != nil {
+			return fmt.Errorf("error setting map key %q: %w", k, err)
+		}
+		m.SetMapIndex(reflect.ValueOf(k).Convert(field.Type().Key()), elem)
+	}
+	field.Set(m)
+	return nil
+}
+
+// isBoolType reports whether t is bool or a pointer to bool
+func isBoolType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Bool
+}
+
 // isEmptyValue checks if a value is empty or zero
 func isEmptyValue(v interface{}) bool {
 	if v == nil {
@@ -3,7 +3,9 @@ package binder
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -820,3 +822,442 @@ func BenchmarkBindWithoutCache(b *testing.B) {
 		_ = Bind(req, &p)
 	}
 }
+
+func TestBindIntOverflow(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		dst   interface{}
+		want  string
+	}{
+		{"Int8TooLarge", "v=300", &struct {
+			V int8 `query:"v"`
+		}{}, "range -128 to 127"},
+		{"Int16TooSmall", "v=-40000", &struct {
+			V int16 `query:"v"`
+		}{}, "range -32768 to 32767"},
+		{"Uint8TooLarge", "v=256", &struct {
+			V uint8 `query:"v"`
+		}{}, "range 0 to 255"},
+		{"Uint32TooLarge", "v=4294967296", &struct {
+			V uint32 `query:"v"`
+		}{}, "range 0 to 4294967295"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/test?"+tt.query, nil)
+			err := Bind(r, tt.dst)
+			if err == nil {
+				t.Fatalf("Binding should fail with overflow")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error to name bounds %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBindNumberRangeFromJSON(t *testing.T) {
+	t.Run("FractionalToInt", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"count": 3.9}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p struct {
+			Count int `body:"count"`
+		}
+		err := Bind(r, &p)
+		if err == nil || !strings.Contains(err.Error(), "fractional") {
+			t.Errorf("Expected fractional value error, got: %v", err)
+		}
+	})
+
+	t.Run("FractionalToUint", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"count": 1.5}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p struct {
+			Count uint `body:"count"`
+		}
+		err := Bind(r, &p)
+		if err == nil || !strings.Contains(err.Error(), "fractional") {
+			t.Errorf("Expected fractional value error, got: %v", err)
+		}
+	})
+
+	t.Run("WholeFloatToInt8", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"count": 100, "big": 1000}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p struct {
+			Count int8 `body:"count"`
+		}
+		if err := Bind(r, &p); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if p.Count != 100 {
+			t.Errorf("Expected Count to be 100, got %d", p.Count)
+		}
+
+		var q struct {
+			Big int8 `body:"big"`
+		}
+		if err := Bind(r, &q); err == nil {
+			t.Errorf("Binding should fail with overflow")
+		}
+	})
+
+	t.Run("Float32Overflow", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"ratio": 1e300}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p struct {
+			Ratio float32 `body:"ratio"`
+		}
+		err := Bind(r, &p)
+		if err == nil || !strings.Contains(err.Error(), "overflows float32") {
+			t.Errorf("Expected float32 overflow error, got: %v", err)
+		}
+	})
+}
+
+func TestBindMaxBodySize(t *testing.T) {
+	payload := `{"name": "` + strings.Repeat("a", 2048) + `"}`
+
+	type params struct {
+		Name string `body:"name"`
+	}
+
+	t.Run("DeclaredLengthTooLarge", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p params
+		err := BindWithOptions(r, &p, BindOptions{MaxBodySize: 1024})
+		if !errors.Is(err, ErrBodyTooLarge) {
+			t.Errorf("Expected ErrBodyTooLarge, got: %v", err)
+		}
+	})
+
+	t.Run("UnderstatedLength", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+		r.ContentLength = 10
+
+		var p params
+		err := BindWithOptions(r, &p, BindOptions{MaxBodySize: 1024})
+		if !errors.Is(err, ErrBodyTooLarge) {
+			t.Errorf("Expected ErrBodyTooLarge, got: %v", err)
+		}
+		var maxErr *http.MaxBytesError
+		if !errors.As(err, &maxErr) || maxErr.Limit != 1024 {
+			t.Errorf("Expected error to wrap *http.MaxBytesError with limit 1024, got: %v", err)
+		}
+	})
+
+	t.Run("WithinLimit", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p params
+		if err := Bind(r, &p); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if len(p.Name) != 2048 {
+			t.Errorf("Expected Name to be bound, got %d bytes", len(p.Name))
+		}
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p params
+		if err := BindWithOptions(r, &p, BindOptions{MaxBodySize: -1}); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+	})
+}
+
+func TestBindUnknownLengthBody(t *testing.T) {
+	type params struct {
+		Name  string `body:"name"`
+		Count int    `body:"count"`
+	}
+
+	t.Run("NoContentLength", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name": "Alice", "count": 3}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.ContentLength = -1
+
+		var p params
+		if err := Bind(r, &p); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if p.Name != "Alice" || p.Count != 3 {
+			t.Errorf("Unexpected result %+v", p)
+		}
+	})
+
+	t.Run("Chunked", func(t *testing.T) {
+		var p params
+		var bindErr error
+		var transferEncoding []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			transferEncoding = r.TransferEncoding
+			bindErr = Bind(r, &p)
+		}))
+		defer server.Close()
+
+		// An io.Pipe has no known length, so the client sends it chunked
+		pr, pw := io.Pipe()
+		go func() {
+			pw.Write([]byte(`{"name": "Alice", `))
+			pw.Write([]byte(`"count": 3}`))
+			pw.Close()
+		}()
+
+		resp, err := http.Post(server.URL, "application/json", pr)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if len(transferEncoding) == 0 || transferEncoding[0] != "chunked" {
+			t.Fatalf("Expected a chunked request, got %v", transferEncoding)
+		}
+		if bindErr != nil {
+			t.Fatalf("Binding failed with error: %v", bindErr)
+		}
+		if p.Name != "Alice" || p.Count != 3 {
+			t.Errorf("Unexpected result %+v", p)
+		}
+	})
+
+	t.Run("StillBounded", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name": "`+strings.Repeat("a", 2048)+`"}`))
+		r.Header.Set("Content-Type", "application/json")
+		r.ContentLength = -1
+
+		var p params
+		err := BindWithOptions(r, &p, BindOptions{MaxBodySize: 1024})
+		if !errors.Is(err, ErrBodyTooLarge) {
+			t.Errorf("Expected ErrBodyTooLarge, got: %v", err)
+		}
+	})
+}
+
+func TestBindWholeBody(t *testing.T) {
+	type item struct {
+		SKU string `json:"sku"`
+		Qty int    `json:"qty"`
+	}
+
+	t.Run("ArrayField", func(t *testing.T) {
+		type request struct {
+			Tenant string `query:"tenant"`
+			Items  []item `body:"-"`
+		}
+		r := httptest.NewRequest("POST", "/orders?tenant=acme", strings.NewReader(`[{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var req request
+		if err := Bind(r, &req); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if req.Tenant != "acme" {
+			t.Errorf("Expected Tenant to be 'acme', got '%s'", req.Tenant)
+		}
+		if len(req.Items) != 2 || req.Items[0] != (item{"a", 1}) || req.Items[1] != (item{"b", 2}) {
+			t.Errorf("Unexpected items %+v", req.Items)
+		}
+	})
+
+	t.Run("ScalarField", func(t *testing.T) {
+		type request struct {
+			Count int `body:",whole"`
+		}
+		r := httptest.NewRequest("POST", "/count", strings.NewReader(`42`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var req request
+		if err := Bind(r, &req); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if req.Count != 42 {
+			t.Errorf("Expected Count to be 42, got %d", req.Count)
+		}
+	})
+
+	t.Run("ObjectAlongsideFields", func(t *testing.T) {
+		type request struct {
+			Name string                 `body:"name"`
+			All  map[string]interface{} `body:"-"`
+			Raw  interface{}            `body:",whole"`
+		}
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name": "Alice", "extra": true}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var req request
+		if err := Bind(r, &req); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if req.Name != "Alice" || len(req.All) != 2 || req.All["extra"] != true {
+			t.Errorf("Unexpected result %+v", req)
+		}
+		if m, ok := req.Raw.(map[string]interface{}); !ok || m["name"] != "Alice" {
+			t.Errorf("Expected Raw to hold the decoded object, got %#v", req.Raw)
+		}
+	})
+
+	t.Run("SliceTarget", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/orders", strings.NewReader(`[{"sku": "a", "qty": 1}, {"sku": "b", "qty": 2}]`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var items []item
+		if err := Bind(r, &items); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if len(items) != 2 || items[1] != (item{"b", 2}) {
+			t.Errorf("Unexpected items %+v", items)
+		}
+	})
+
+	t.Run("TypedMapTarget", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/stock", strings.NewReader(`{"a": 1, "b": "2"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var stock map[string]int
+		if err := Bind(r, &stock); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if len(stock) != 2 || stock["a"] != 1 || stock["b"] != 2 {
+			t.Errorf("Unexpected stock %v", stock)
+		}
+	})
+
+	t.Run("EmptyBody", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/orders", nil)
+
+		var items []item
+		if err := Bind(r, &items); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if items != nil {
+			t.Errorf("Expected items to stay nil, got %+v", items)
+		}
+	})
+
+	t.Run("ArrayLimit", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/numbers", strings.NewReader(`[1, 2, 3]`))
+		r.Header.Set("Content-Type", "application/json")
+
+		var numbers []int
+		err := BindWithOptions(r, &numbers, BindOptions{MaxArrayLength: 2})
+		if !errors.Is(err, ErrArrayTooLong) {
+			t.Errorf("Expected ErrArrayTooLong, got %v", err)
+		}
+	})
+}
+
+func TestBindExplicitNull(t *testing.T) {
+	type nested struct {
+		City string `json:"city"`
+	}
+	type request struct {
+		Name    string            `json:"name"`
+		Age     int               `json:"age"`
+		Nick    *string           `json:"nick"`
+		Count   *int              `json:"count"`
+		Tags    []string          `json:"tags"`
+		Labels  map[string]string `json:"labels"`
+		Address *nested           `json:"address"`
+		Extra   interface{}       `json:"extra"`
+		Scores  []*int            `json:"scores"`
+	}
+	body := `{"name": null, "age": null, "nick": null, "tags": null, "labels": null, "address": null, "extra": null, "scores": [1, null]}`
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		return r
+	}
+	prefilled := func() request {
+		nick := "Al"
+		return request{
+			Name:    "Alice",
+			Age:     30,
+			Nick:    &nick,
+			Tags:    []string{"a"},
+			Labels:  map[string]string{"a": "b"},
+			Address: &nested{City: "Springfield"},
+			Extra:   1,
+		}
+	}
+
+	t.Run("Keep", func(t *testing.T) {
+		req := prefilled()
+		if err := Bind(newRequest(), &req); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if req.Name != "Alice" || req.Age != 30 {
+			t.Errorf("Expected value fields to be kept, got %q and %d", req.Name, req.Age)
+		}
+		if req.Nick != nil || req.Tags != nil || req.Labels != nil || req.Address != nil || req.Extra != nil {
+			t.Errorf("Expected nullable fields to be reset, got %+v", req)
+		}
+		if req.Count != nil {
+			t.Errorf("Expected absent pointer to stay nil, got %v", req.Count)
+		}
+		if len(req.Scores) != 2 || req.Scores[0] == nil || *req.Scores[0] != 1 || req.Scores[1] != nil {
+			t.Errorf("Expected null slice element to be nil, got %v", req.Scores)
+		}
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		req := prefilled()
+		if err := BindWithOptions(newRequest(), &req, BindOptions{NullValues: NullZero}); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if req.Name != "" || req.Age != 0 || req.Nick != nil {
+			t.Errorf("Expected fields to be zeroed, got %+v", req)
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		req := prefilled()
+		err := BindWithOptions(newRequest(), &req, BindOptions{NullValues: NullReject})
+		if !errors.Is(err, ErrNullValue) {
+			t.Errorf("Expected ErrNullValue, got %v", err)
+		}
+		if err == nil || !strings.Contains(err.Error(), "Name") {
+			t.Errorf("Expected error to name the field, got %v", err)
+		}
+	})
+}
+
+func TestBindPointerNotAllocatedOnError(t *testing.T) {
+	type request struct {
+		Count *int       `json:"count"`
+		When  *time.Time `json:"when"`
+	}
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(`{"count": "many"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	var req request
+	if err := Bind(r, &req); err == nil {
+		t.Fatal("Expected conversion error")
+	}
+	if req.Count != nil {
+		t.Errorf("Expected Count to stay nil after a failed conversion, got %v", *req.Count)
+	}
+
+	r = httptest.NewRequest("POST", "/test", strings.NewReader(`{"when": "yesterday"}`))
+	r.Header.Set("Content-Type", "application/json")
+	if err := Bind(r, &req); err == nil {
+		t.Fatal("Expected conversion error")
+	}
+	if req.When != nil {
+		t.Errorf("Expected When to stay nil after a failed conversion, got %v", req.When)
+	}
+}
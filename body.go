@@ -0,0 +1,179 @@
+package binder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// spoolFile is a request body spooled to a temporary file
+type spoolFile struct {
+	file *os.File
+	size int64
+	once sync.Once
+}
+
+// remove closes and deletes the temporary file. It is safe to call more than once.
+func (s *spoolFile) remove() {
+	s.once.Do(func() {
+		s.file.Close()
+		os.Remove(s.file.Name())
+	})
+}
+
+// reader returns a new reader over the whole spooled body
+func (s *spoolFile) reader() *io.SectionReader {
+	return io.NewSectionReader(s.file, 0, s.size)
+}
+
+// bufferedBody is the r.Body installed for a request held in memory. It
+// lets a later bind reuse the bytes instead of copying them again.
+type bufferedBody struct {
+	*bytes.Reader
+	data []byte
+}
+
+// Close implements io.Closer
+func (b *bufferedBody) Close() error {
+	return nil
+}
+
+// spooledBody is the r.Body installed for a spooled request. Closing it
+// deletes the temporary file.
+type spooledBody struct {
+	*io.SectionReader
+	spool *spoolFile
+}
+
+// Close deletes the temporary file backing the body
+func (b *spooledBody) Close() error {
+	b.spool.remove()
+	return nil
+}
+
+// bufferBody reads the request body once, replaces r.Body with a copy that
+// can be read again and returns a reader over the same bytes and its size.
+//
+// Bodies larger than BindOptions.SpoolThreshold are copied to a temporary
+// file instead of memory. The file is removed when the request's context
+// is done, which for server requests is when the handler returns, or when
+// r.Body is closed.
+func bufferBody(r *http.Request, opts *BindOptions) (io.Reader, int64, error) {
+	// A body buffered by an earlier bind is reused rather than copied again
+	switch b := r.Body.(type) {
+	case *bufferedBody:
+		r.Body = &bufferedBody{Reader: bytes.NewReader(b.data), data: b.data}
+		return bytes.NewReader(b.data), int64(len(b.data)), nil
+	case *spooledBody:
+		r.Body = &spooledBody{SectionReader: b.spool.reader(), spool: b.spool}
+		return b.spool.reader(), b.spool.size, nil
+	}
+
+	limit := opts.maxBodySize()
+	if limit >= 0 && r.ContentLength > limit {
+		return nil, 0, fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)
+	}
+	var reader io.Reader = r.Body
+	if limit >= 0 {
+		reader = http.MaxBytesReader(nil, r.Body, limit)
+	}
+
+	// Read up to the spool threshold into memory
+	threshold := opts.SpoolThreshold
+	head := reader
+	if threshold > 0 {
+		head = io.LimitReader(reader, threshold+1)
+	}
+	bodyBytes, err := io.ReadAll(head)
+	if err != nil {
+		return nil, 0, bodyReadError(err)
+	}
+	if threshold <= 0 || int64(len(bodyBytes)) <= threshold {
+		r.Body = &bufferedBody{Reader: bytes.NewReader(bodyBytes), data: bodyBytes}
+		return bytes.NewReader(bodyBytes), int64(len(bodyBytes)), nil
+	}
+
+	spool, err := spoolBody(bodyBytes, reader, opts.SpoolDir)
+	if err != nil {
+		return nil, 0, err
+	}
+	context.AfterFunc(r.Context(), spool.remove)
+	r.Body = &spooledBody{SectionReader: spool.reader(), spool: spool}
+	return spool.reader(), spool.size, nil
+}
+
+// spoolBody writes head followed by the rest of the body to a temporary file
+func spoolBody(head []byte, rest io.Reader, dir string) (*spoolFile, error) {
+	file, err := os.CreateTemp(dir, "binder-body-*")
+	if err != nil {
+		return nil, fmt.Errorf("error spooling request body: %w", err)
+	}
+	spool := &spoolFile{file: file}
+
+	if _, err := file.Write(head); err != nil {
+		spool.remove()
+		return nil, fmt.Errorf("error spooling request body: %w", err)
+	}
+	n, err := io.Copy(file, rest)
+	if err != nil {
+		spool.remove()
+		return nil, bodyReadError(err)
+	}
+	spool.size = int64(len(head)) + n
+	return spool, nil
+}
+
+// bindRawBody sets a field tagged `body:",raw"` from the body buffered by
+// parseRequestBody. Fields of byte slice or string type receive a copy of
+// the bytes; interface fields such as io.Reader receive a reader over them.
+// A body that has not been buffered, as when streaming, is left alone.
+func bindRawBody(r *http.Request, field reflect.Value, opts *BindOptions) error {
+	switch r.Body.(type) {
+	case *bufferedBody, *spooledBody:
+	default:
+		return nil
+	}
+	body, _, err := bufferBody(r, opts)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case field.Kind() == reflect.Interface:
+		if !reflect.TypeOf(body).Implements(field.Type()) {
+			return fmt.Errorf("unsupported raw body type: %s", field.Type())
+		}
+		field.Set(reflect.ValueOf(body))
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8:
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return bodyReadError(err)
+		}
+		field.SetBytes(data)
+	case field.Kind() == reflect.String:
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return bodyReadError(err)
+		}
+		field.SetString(string(data))
+	default:
+		return fmt.Errorf("unsupported raw body type: %s", field.Type())
+	}
+	return nil
+}
+
+// bodyReadError maps errors from reading the request body, turning
+// http.MaxBytesReader's error into ErrBodyTooLarge
+func bodyReadError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return fmt.Errorf("%w: %w", ErrBodyTooLarge, maxErr)
+	}
+	return fmt.Errorf("error reading request body: %w", err)
+}
@@ -0,0 +1,270 @@
+package binder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func spooledFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "binder-body-*"))
+	if err != nil {
+		t.Fatalf("Failed to list spool directory: %v", err)
+	}
+	return files
+}
+
+func TestBindSpooledBody(t *testing.T) {
+	type params struct {
+		Name string `body:"name"`
+		Note string `body:"note"`
+	}
+	payload := `{"name": "Alice", "note": "` + strings.Repeat("x", 1024) + `"}`
+
+	t.Run("Spooled", func(t *testing.T) {
+		dir := t.TempDir()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload)).WithContext(ctx)
+		r.Header.Set("Content-Type", "application/json")
+		opts := BindOptions{SpoolThreshold: 64, SpoolDir: dir}
+
+		var p params
+		if err := BindWithOptions(r, &p, opts); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if p.Name != "Alice" || len(p.Note) != 1024 {
+			t.Errorf("Unexpected result %q with note length %d", p.Name, len(p.Note))
+		}
+		if files := spooledFiles(t, dir); len(files) != 1 {
+			t.Fatalf("Expected 1 spooled file, got %d", len(files))
+		}
+
+		// Binding again reuses the spooled file
+		var again params
+		if err := BindWithOptions(r, &again, opts); err != nil {
+			t.Fatalf("Second binding failed with error: %v", err)
+		}
+		if again != p {
+			t.Errorf("Expected second bind to match first, got %+v", again)
+		}
+		if files := spooledFiles(t, dir); len(files) != 1 {
+			t.Errorf("Expected 1 spooled file after second bind, got %d", len(files))
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("Failed to read restored body: %v", err)
+		}
+		if string(body) != payload {
+			t.Errorf("Expected restored body to match payload")
+		}
+
+		cancel()
+		for range 100 {
+			if len(spooledFiles(t, dir)) == 0 {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		t.Errorf("Expected spooled file to be removed when the context is done")
+	})
+
+	t.Run("RemovedOnClose", func(t *testing.T) {
+		dir := t.TempDir()
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p params
+		if err := BindWithOptions(r, &p, BindOptions{SpoolThreshold: 64, SpoolDir: dir}); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		r.Body.Close()
+		if files := spooledFiles(t, dir); len(files) != 0 {
+			t.Errorf("Expected spooled file to be removed on close, got %v", files)
+		}
+	})
+
+	t.Run("BelowThreshold", func(t *testing.T) {
+		dir := t.TempDir()
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p params
+		if err := BindWithOptions(r, &p, BindOptions{SpoolThreshold: 1 << 20, SpoolDir: dir}); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if p.Name != "Alice" {
+			t.Errorf("Expected Name to be 'Alice', got '%s'", p.Name)
+		}
+		if files := spooledFiles(t, dir); len(files) != 0 {
+			t.Errorf("Expected no spooled files, got %v", files)
+		}
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		dir := t.TempDir()
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+		r.ContentLength = -1
+
+		var p params
+		err := BindWithOptions(r, &p, BindOptions{SpoolThreshold: 64, SpoolDir: dir, MaxBodySize: 512})
+		if !errors.Is(err, ErrBodyTooLarge) {
+			t.Errorf("Expected ErrBodyTooLarge, got %v", err)
+		}
+		if files := spooledFiles(t, dir); len(files) != 0 {
+			t.Errorf("Expected partial spool file to be removed, got %v", files)
+		}
+	})
+
+	t.Run("MissingDir", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p params
+		err := BindWithOptions(r, &p, BindOptions{SpoolThreshold: 64, SpoolDir: filepath.Join(t.TempDir(), "missing")})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected os.ErrNotExist, got %v", err)
+		}
+	})
+}
+
+func TestHasTagOption(t *testing.T) {
+	tests := []struct {
+		tag    string
+		option string
+		want   bool
+	}{
+		{",raw", "raw", true},
+		{"name,omitempty,whole", "whole", true},
+		{"raw", "raw", false},
+		{"", "raw", false},
+		{"name,", "", true},
+	}
+
+	for _, tt := range tests {
+		if got := hasTagOption(tt.tag, tt.option); got != tt.want {
+			t.Errorf("Expected hasTagOption(%q, %q) to be %v, got %v", tt.tag, tt.option, tt.want, got)
+		}
+	}
+	if allocs := testing.AllocsPerRun(10, func() { hasTagOption("name,omitempty,raw", "whole") }); allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestBindRawBody(t *testing.T) {
+	payload := `{"event": "push", "id": 7}`
+
+	type webhook struct {
+		Event     string          `body:"event"`
+		ID        int             `body:"id"`
+		Raw       []byte          `body:",raw"`
+		Text      string          `body:",raw"`
+		Message   json.RawMessage `body:",raw"`
+		Reader    io.Reader       `body:",raw"`
+		Signature string          `query:"sig"`
+	}
+
+	r := httptest.NewRequest("POST", "/hook?sig=abc", strings.NewReader(payload))
+	r.Header.Set("Content-Type", "application/json")
+
+	var w webhook
+	if err := Bind(r, &w); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if w.Event != "push" || w.ID != 7 || w.Signature != "abc" {
+		t.Errorf("Unexpected bound fields %+v", w)
+	}
+	if string(w.Raw) != payload {
+		t.Errorf("Expected Raw to be %q, got %q", payload, w.Raw)
+	}
+	if w.Text != payload {
+		t.Errorf("Expected Text to be %q, got %q", payload, w.Text)
+	}
+	if string(w.Message) != payload {
+		t.Errorf("Expected Message to be %q, got %q", payload, w.Message)
+	}
+	if w.Reader == nil {
+		t.Fatal("Expected Reader to be set")
+	}
+	if data, _ := io.ReadAll(w.Reader); string(data) != payload {
+		t.Errorf("Expected Reader to yield %q, got %q", payload, data)
+	}
+
+	// The request body is still available afterwards
+	if data, _ := io.ReadAll(r.Body); string(data) != payload {
+		t.Errorf("Expected restored body to be %q, got %q", payload, data)
+	}
+}
+
+func TestBindRawBodyAsReceived(t *testing.T) {
+	type webhook struct {
+		Event string `body:"event"`
+		Raw   []byte `body:",raw"`
+	}
+
+	t.Run("Compressed", func(t *testing.T) {
+		compressed := compress(t, "gzip", []byte(`{"event": "push"}`))
+		r := httptest.NewRequest("POST", "/hook", bytes.NewReader(compressed))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Content-Encoding", "gzip")
+
+		var w webhook
+		if err := Bind(r, &w); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if w.Event != "push" {
+			t.Errorf("Expected Event to be 'push', got '%s'", w.Event)
+		}
+		if !bytes.Equal(w.Raw, compressed) {
+			t.Errorf("Expected Raw to hold the compressed bytes")
+		}
+	})
+
+	t.Run("Spooled", func(t *testing.T) {
+		payload := `{"event": "` + strings.Repeat("x", 256) + `"}`
+		r := httptest.NewRequest("POST", "/hook", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+
+		var w webhook
+		if err := BindWithOptions(r, &w, BindOptions{SpoolThreshold: 64, SpoolDir: t.TempDir()}); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		defer r.Body.Close()
+		if string(w.Raw) != payload {
+			t.Errorf("Expected Raw to hold the spooled body")
+		}
+	})
+
+	t.Run("NoBody", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/hook", nil)
+
+		var w webhook
+		if err := Bind(r, &w); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if w.Raw != nil {
+			t.Errorf("Expected Raw to be nil, got %q", w.Raw)
+		}
+	})
+
+	t.Run("UnsupportedType", func(t *testing.T) {
+		var w struct {
+			Raw int `body:",raw"`
+		}
+		r := httptest.NewRequest("POST", "/hook", strings.NewReader(`{}`))
+		if err := Bind(r, &w); err == nil {
+			t.Error("Expected error for int raw body field")
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package binder
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// sourceRef names a request source and the key a field reads from it
+type sourceRef struct {
+	Source string // "path", "query", "header", "cookie" or "body"
+	Name   string
+}
+
+// parseBindTag parses a bind tag such as "header=X-Api-Key,query=api_key",
+// returning its sources in order of precedence. A source given without a
+// key, as in `bind:"query"`, reads the key naming derives from the field
+// name. An omitempty entry is allowed anywhere in the list, and spaces
+// around entries are ignored, as in "header=X-Api-Key, query=api_key".
+func parseBindTag(tag, fieldName string, naming NamingStrategy) ([]sourceRef, error) {
+	var refs []sourceRef
+	for _, entry := range strings.Split(tag, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "omitempty" {
+			continue
+		}
+		source, name, ok := strings.Cut(entry, "=")
+		source, name = strings.TrimSpace(source), strings.TrimSpace(name)
+		if ok && name == "" {
+			return nil, fmt.Errorf("invalid bind tag %q: empty key for source %q", tag, source)
+		}
+		if !ok {
+			name = naming.name(fieldName)
+		}
+		switch source {
+		case path, query, header, cookie, body:
+			refs = append(refs, sourceRef{Source: source, Name: name})
+		default:
+			return nil, fmt.Errorf("invalid bind tag %q: unknown source %q", tag, source)
+		}
+	}
+	return refs, nil
+}
+
+// extractChainValue gets the value for a field with a bind tag from the
+// first of its sources that is present in the request
+func extractChainValue(r *http.Request, field reflect.StructField, bodyData interface{}, opts *BindOptions) (interface{}, sourceRef, error) {
+	refs, err := parseBindTag(field.Tag.Get(bind), field.Name, opts.Naming)
+	if err != nil {
+		return nil, sourceRef{}, fmt.Errorf("error binding field %s: %w", field.Name, err)
+	}
+	for _, ref := range refs {
+		if v, from := lookupSource(r, field, ref, bodyData, opts); from.Source != "" {
+			return v, from, nil
+		}
+	}
+	return nil, sourceRef{}, nil
+}
+
+// lookupSource gets the value of one named key from one request source.
+// It returns ref if the key is present and an empty sourceRef otherwise.
+func lookupSource(r *http.Request, field reflect.StructField, ref sourceRef, bodyData interface{}, opts *BindOptions) (interface{}, sourceRef) {
+	var v interface{}
+	var exists bool
+
+	switch ref.Source {
+	case path:
+		s := r.PathValue(ref.Name)
+		v, exists = s, s != ""
+
+	case query:
+		values := r.URL.Query()
+		name := queryKey(values, ref.Name, opts.KeyMatching)
+		s := values.Get(name)
+		switch {
+		case s == "" && opts.QueryFlags && values.Has(name) && isBoolType(field.Type):
+			// A bare ?flag means true
+			v, exists = "true", true
+		case s == "" && !values.Has(name) && hasNestedKey(values, name):
+			// Nested keys such as filter[status] or filter.status
+			v, exists = expandKeys(valuesMap(values))[name]
+		default:
+			v, exists = s, s != ""
+		}
+
+	case header:
+		switch values := r.Header.Values(ref.Name); len(values) {
+		case 0:
+		case 1:
+			v, exists = values[0], values[0] != ""
+		default:
+			list := make([]interface{}, len(values))
+			for i := range values {
+				list[i] = values[i]
+			}
+			v, exists = list, true
+		}
+
+	case cookie:
+		if c, err := r.Cookie(ref.Name); err == nil {
+			v, exists = c.Value, true
+		}
+
+	case body:
+		fields, _ := bodyData.(map[string]interface{})
+		v, exists = lookupKey(fields, ref.Name, opts)
+	}
+
+	if !exists {
+		return nil, sourceRef{}
+	}
+	return v, ref
+}
+
+// lookupChainField finds the value for a field with a bind tag in a nested
+// body object, where only its body and query sources can apply
+func lookupChainField(data map[string]interface{}, field reflect.StructField, opts *BindOptions) (interface{}, bool) {
+	refs, _ := parseBindTag(field.Tag.Get(bind), field.Name, opts.Naming)
+	for _, ref := range refs {
+		if ref.Source != body && ref.Source != query {
+			continue
+		}
+		if v, ok := lookupKey(data, ref.Name, opts); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
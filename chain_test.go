@@ -0,0 +1,181 @@
+package binder
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type chainRequest struct {
+	APIKey  string   `bind:"header=X-Api-Key,query=api_key,cookie=api_key"`
+	Page    int      `bind:"body=page,query=page"`
+	Tags    []string `bind:"header=X-Tag"`
+	Present Presence
+}
+
+func TestParseBindTag(t *testing.T) {
+	refs, err := parseBindTag("header=X-Api-Key,query=api_key,omitempty", "APIKey", NameExact)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := []sourceRef{{header, "X-Api-Key"}, {query, "api_key"}}
+	if !reflect.DeepEqual(refs, want) {
+		t.Errorf("Expected %v, got %v", want, refs)
+	}
+
+	refs, err = parseBindTag("header=X-Api-Key, query = api_key , omitempty", "APIKey", NameExact)
+	if err != nil || !reflect.DeepEqual(refs, want) {
+		t.Errorf("Expected spaces around entries to be ignored, got %v, %v", refs, err)
+	}
+
+	for _, tag := range []string{"query=", "query= ", "form=name", "form"} {
+		if _, err := parseBindTag(tag, "Name", NameExact); err == nil {
+			t.Errorf("Expected error for tag %q", tag)
+		}
+	}
+}
+
+func TestBindSourceChain(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		header     string
+		cookie     string
+		body       string
+		wantKey    string
+		wantSource string
+		wantPage   int
+	}{
+		{
+			name:       "header wins",
+			url:        "/?api_key=from-query",
+			header:     "from-header",
+			cookie:     "from-cookie",
+			wantKey:    "from-header",
+			wantSource: "header",
+		},
+		{
+			name:       "query fallback",
+			url:        "/?api_key=from-query",
+			cookie:     "from-cookie",
+			wantKey:    "from-query",
+			wantSource: "query",
+		},
+		{
+			name:       "cookie fallback",
+			url:        "/",
+			cookie:     "from-cookie",
+			wantKey:    "from-cookie",
+			wantSource: "cookie",
+		},
+		{
+			name:       "absent",
+			url:        "/",
+			wantSource: "",
+		},
+		{
+			name:     "body before query",
+			url:      "/?page=2",
+			body:     `{"page":3}`,
+			wantPage: 3,
+		},
+		{
+			name:     "query when body lacks key",
+			url:      "/?page=2",
+			body:     `{}`,
+			wantPage: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.header != "" {
+				req.Header.Set("X-Api-Key", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "api_key", Value: tt.cookie})
+			}
+
+			var r chainRequest
+			if err := Bind(req, &r); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if r.APIKey != tt.wantKey {
+				t.Errorf("Expected APIKey to be %q, got %q", tt.wantKey, r.APIKey)
+			}
+			if got := r.Present.Source("APIKey"); got != tt.wantSource {
+				t.Errorf("Expected APIKey source to be %q, got %q", tt.wantSource, got)
+			}
+			if r.Page != tt.wantPage {
+				t.Errorf("Expected Page to be %d, got %d", tt.wantPage, r.Page)
+			}
+		})
+	}
+}
+
+func TestBindSourceChainPresence(t *testing.T) {
+	req := httptest.NewRequest("GET", "/?api_key=k", nil)
+	req.Header.Add("X-Tag", "a")
+	req.Header.Add("X-Tag", "b")
+
+	var r chainRequest
+	if err := Bind(req, &r); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(r.Tags, []string{"a", "b"}) {
+		t.Errorf("Expected Tags to be [a b], got %v", r.Tags)
+	}
+	if !r.Present.Has("api_key") || r.Present.Has("X-Api-Key") {
+		t.Errorf("Expected presence of the key used, got %v", r.Present.Keys())
+	}
+	if got := r.Present.Source("Tags"); got != "header" {
+		t.Errorf("Expected Tags source to be header, got %q", got)
+	}
+}
+
+func TestBindSourceChainNested(t *testing.T) {
+	type address struct {
+		City string `bind:"body=city,body=town"`
+	}
+	type request struct {
+		Address address `json:"address"`
+	}
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"address":{"town":"Springfield"}}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var r request
+	if err := BindWithOptions(req, &r, BindOptions{RejectUnknownFields: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if r.Address.City != "Springfield" {
+		t.Errorf("Expected City to be Springfield, got %q", r.Address.City)
+	}
+}
+
+func TestBindSourceChainInvalidTag(t *testing.T) {
+	type request struct {
+		Name string `bind:"form=name"`
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	var r request
+	err := Bind(req, &r)
+	if err == nil || !strings.Contains(err.Error(), `unknown source "form"`) {
+		t.Errorf("Expected unknown source error, got %v", err)
+	}
+}
+
+func TestBindSourceChainStrict(t *testing.T) {
+	req := httptest.NewRequest("GET", "/?api_key=k&page=1&other=x", nil)
+
+	var r chainRequest
+	err := BindWithOptions(req, &r, BindOptions{RejectUnknownFields: true})
+	if err == nil || err.Error() != `unknown field: query "other"` {
+		t.Errorf("Expected only other to be unknown, got %v", err)
+	}
+}
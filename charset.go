@@ -0,0 +1,201 @@
+package binder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 is returned when BindOptions.StrictCharset is set and a
+// request body, after any charset conversion, is not valid UTF-8.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8 in request body")
+
+// charset identifies a supported character encoding by its canonical name
+type charset string
+
+// Supported charsets
+const (
+	charsetUTF8        charset = "utf-8"
+	charsetWindows1252 charset = "windows-1252"
+	charsetISO885915   charset = "iso-8859-15"
+	charsetUTF16       charset = "utf-16"
+	charsetUTF16LE     charset = "utf-16le"
+	charsetUTF16BE     charset = "utf-16be"
+)
+
+// charsetLabels maps lower-case charset labels to the charset they select.
+// As in the WHATWG Encoding Standard, Latin-1 labels select Windows-1252:
+// the two only differ in the 0x80-0x9F range, where legacy senders almost
+// always mean Windows-1252.
+var charsetLabels = map[string]charset{
+	"":                charsetUTF8,
+	"utf-8":           charsetUTF8,
+	"utf8":            charsetUTF8,
+	"us-ascii":        charsetUTF8,
+	"ascii":           charsetUTF8,
+	"windows-1252":    charsetWindows1252,
+	"cp1252":          charsetWindows1252,
+	"x-cp1252":        charsetWindows1252,
+	"iso-8859-1":      charsetWindows1252,
+	"iso8859-1":       charsetWindows1252,
+	"iso_8859-1":      charsetWindows1252,
+	"latin1":          charsetWindows1252,
+	"l1":              charsetWindows1252,
+	"iso-8859-15":     charsetISO885915,
+	"iso8859-15":      charsetISO885915,
+	"iso_8859-15":     charsetISO885915,
+	"latin-9":         charsetISO885915,
+	"utf-16":          charsetUTF16,
+	"utf-16le":        charsetUTF16LE,
+	"utf-16be":        charsetUTF16BE,
+	"unicode-1-1-utf": charsetUTF16,
+}
+
+// windows1252High holds the code points for bytes 0x80-0x9F in Windows-1252.
+// Bytes the encoding leaves undefined map to the C1 control of the same value.
+var windows1252High = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', '\u008D', 'Ž', '\u008F',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', '\u009D', 'ž', 'Ÿ',
+}
+
+// iso885915Diff holds the bytes where ISO-8859-15 differs from ISO-8859-1
+var iso885915Diff = map[byte]rune{
+	0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž',
+	0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ',
+}
+
+// lookupCharset resolves a charset label, reporting whether it is supported
+func lookupCharset(label string) (charset, bool) {
+	cs, ok := charsetLabels[strings.ToLower(strings.Trim(label, `" `))]
+	return cs, ok
+}
+
+// toUTF8 converts data from cs to UTF-8
+func (cs charset) toUTF8(data []byte) ([]byte, error) {
+	switch cs {
+	case charsetWindows1252, charsetISO885915:
+		return cs.decodeSingleByte(data), nil
+	case charsetUTF16, charsetUTF16LE, charsetUTF16BE:
+		return cs.decodeUTF16(data)
+	default:
+		return data, nil
+	}
+}
+
+// decodeSingleByte converts data from a single-byte charset to UTF-8
+func (cs charset) decodeSingleByte(data []byte) []byte {
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	for _, b := range data {
+		switch {
+		case b < 0x80:
+			buf.WriteByte(b)
+		case cs == charsetWindows1252 && b < 0xA0:
+			buf.WriteRune(windows1252High[b-0x80])
+		case cs == charsetISO885915 && iso885915Diff[b] != 0:
+			buf.WriteRune(iso885915Diff[b])
+		default:
+			buf.WriteRune(rune(b))
+		}
+	}
+	return buf.Bytes()
+}
+
+// decodeUTF16 converts UTF-16 data to UTF-8. Plain "utf-16" honours a byte
+// order mark and otherwise assumes big-endian as RFC 2781 specifies.
+func (cs charset) decodeUTF16(data []byte) ([]byte, error) {
+	var order binary.ByteOrder = binary.BigEndian
+	if cs == charsetUTF16LE {
+		order = binary.LittleEndian
+	}
+	if len(data) >= 2 {
+		switch {
+		case data[0] == 0xFE && data[1] == 0xFF:
+			order, data = binary.BigEndian, data[2:]
+		case data[0] == 0xFF && data[1] == 0xFE:
+			order, data = binary.LittleEndian, data[2:]
+		}
+	}
+	if len(data)%2 != 0 {
+		return nil, errors.New("truncated UTF-16 data")
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	for _, r := range utf16.Decode(units) {
+		buf.WriteRune(r)
+	}
+	return buf.Bytes(), nil
+}
+
+// toUTF8Reader reads a body in charset cs and returns it converted to UTF-8,
+// failing with ErrInvalidUTF8 if strict is set and the result is invalid
+func toUTF8Reader(body io.Reader, cs charset, strict bool) (io.Reader, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = cs.toUTF8(data); err != nil {
+		return nil, err
+	}
+	if strict && !utf8.Valid(data) {
+		return nil, ErrInvalidUTF8
+	}
+	return bytes.NewReader(data), nil
+}
+
+// xmlCharsetReader lets encoding/xml read documents whose prolog declares
+// one of the supported charsets
+func xmlCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	cs, ok := lookupCharset(label)
+	if !ok {
+		return nil, fmt.Errorf("unsupported charset %q", label)
+	}
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	data, err = cs.toUTF8(data)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// checkUTF8 reports the first string in decoded body data that is not valid UTF-8
+func checkUTF8(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		if !utf8.ValidString(v) {
+			return ErrInvalidUTF8
+		}
+	case []interface{}:
+		for _, elem := range v {
+			if err := checkUTF8(elem); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		for k, elem := range v {
+			if !utf8.ValidString(k) {
+				return ErrInvalidUTF8
+			}
+			if err := checkUTF8(elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
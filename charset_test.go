@@ -0,0 +1,195 @@
+package binder
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestParseMediaType(t *testing.T) {
+	mediaType, params := parseMediaType(`Text/Plain; Charset="ISO-8859-1"; format=flowed`)
+
+	if mediaType != "text/plain" {
+		t.Errorf("Expected media type text/plain, got %q", mediaType)
+	}
+	expected := map[string]string{"charset": "ISO-8859-1", "format": "flowed"}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("Expected params %v, got %v", expected, params)
+	}
+}
+
+func TestBindLatin1Form(t *testing.T) {
+	// "name=José Müller" percent-encoded as Latin-1 bytes
+	r := httptest.NewRequest("POST", "/test", bytes.NewBufferString("name=Jos%E9+M%FCller&city=K%F6ln"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=ISO-8859-1")
+
+	type params struct {
+		Name string `body:"name"`
+		City string `body:"city"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.Name != "José Müller" || p.City != "Köln" {
+		t.Errorf("Unexpected result %+v", p)
+	}
+}
+
+func TestBindWindows1252JSON(t *testing.T) {
+	// {"price": "€5 – “special”"} in Windows-1252
+	payload := []byte("{\"price\": \"\x805 \x96 \x93special\x94\"}")
+	r := httptest.NewRequest("POST", "/test", bytes.NewReader(payload))
+	r.Header.Set("Content-Type", "application/json; charset=windows-1252")
+
+	type params struct {
+		Price string `body:"price"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.Price != "€5 – “special”" {
+		t.Errorf("Expected Price to be €5 – “special”, got %q", p.Price)
+	}
+}
+
+func TestBindUTF16JSON(t *testing.T) {
+	encode := func(s string, bom bool) []byte {
+		var buf bytes.Buffer
+		if bom {
+			buf.Write([]byte{0xFF, 0xFE})
+		}
+		for _, u := range utf16.Encode([]rune(s)) {
+			buf.Write([]byte{byte(u), byte(u >> 8)})
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		charset string
+		payload []byte
+	}{
+		{"utf-16", encode(`{"name": "Zoë 😀"}`, true)},
+		{"UTF-16LE", encode(`{"name": "Zoë 😀"}`, false)},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/test", bytes.NewReader(tt.payload))
+		r.Header.Set("Content-Type", "application/json; charset="+tt.charset)
+
+		var p struct {
+			Name string `body:"name"`
+		}
+		if err := Bind(r, &p); err != nil {
+			t.Fatalf("%s: binding failed with error: %v", tt.charset, err)
+		}
+		if p.Name != "Zoë 😀" {
+			t.Errorf("%s: expected Name to be Zoë 😀, got %q", tt.charset, p.Name)
+		}
+	}
+}
+
+func TestBindUTF16Form(t *testing.T) {
+	var payload bytes.Buffer
+	for _, u := range utf16.Encode([]rune("name=Zoë&city=K%C3%B6ln")) {
+		payload.Write([]byte{byte(u), byte(u >> 8)})
+	}
+	r := httptest.NewRequest("POST", "/test", &payload)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-16le")
+
+	var p struct {
+		Name string `body:"name"`
+		City string `body:"city"`
+	}
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.Name != "Zoë" || p.City != "Köln" {
+		t.Errorf("Expected {Zoë Köln}, got %+v", p)
+	}
+}
+
+func TestBindXMLCharset(t *testing.T) {
+	type params struct {
+		Name string `xml:"name"`
+	}
+
+	t.Run("Prolog", func(t *testing.T) {
+		payload := []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><p><name>Ren\xe9e</name></p>")
+		r := httptest.NewRequest("POST", "/test", bytes.NewReader(payload))
+		r.Header.Set("Content-Type", "application/xml")
+
+		var p params
+		if err := Bind(r, &p); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if p.Name != "Renée" {
+			t.Errorf("Expected Name to be Renée, got %q", p.Name)
+		}
+	})
+
+	t.Run("HeaderOverridesProlog", func(t *testing.T) {
+		payload := []byte("<?xml version=\"1.0\" encoding=\"UTF-8\"?><p><name>Ren\xe9e</name></p>")
+		r := httptest.NewRequest("POST", "/test", bytes.NewReader(payload))
+		r.Header.Set("Content-Type", "text/xml; charset=latin1")
+
+		var p params
+		if err := Bind(r, &p); err != nil {
+			t.Fatalf("Binding failed with error: %v", err)
+		}
+		if p.Name != "Renée" {
+			t.Errorf("Expected Name to be Renée, got %q", p.Name)
+		}
+	})
+}
+
+func TestBindStrictCharset(t *testing.T) {
+	type params struct {
+		Name string `body:"name"`
+	}
+
+	t.Run("InvalidUTF8JSON", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", bytes.NewReader([]byte("{\"name\": \"Ren\xe9e\"}")))
+		r.Header.Set("Content-Type", "application/json")
+
+		var p params
+		err := BindWithOptions(r, &p, BindOptions{StrictCharset: true})
+		if !errors.Is(err, ErrInvalidUTF8) {
+			t.Errorf("Expected ErrInvalidUTF8, got: %v", err)
+		}
+	})
+
+	t.Run("InvalidUTF8Form", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", bytes.NewBufferString("name=Ren%E9e"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		var p params
+		err := BindWithOptions(r, &p, BindOptions{StrictCharset: true})
+		if !errors.Is(err, ErrInvalidUTF8) {
+			t.Errorf("Expected ErrInvalidUTF8, got: %v", err)
+		}
+	})
+
+	t.Run("UnknownCharset", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"name": "x"}`))
+		r.Header.Set("Content-Type", "application/json; charset=koi8-r")
+
+		var p params
+		if err := Bind(r, &p); err != nil || p.Name != "x" {
+			t.Fatalf("Unknown charsets should be treated as UTF-8 by default, got %+v, %v", p, err)
+		}
+
+		r = httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"name": "x"}`))
+		r.Header.Set("Content-Type", "application/json; charset=koi8-r")
+		err := BindWithOptions(r, &p, BindOptions{StrictCharset: true})
+		if !errors.Is(err, ErrUnsupportedMediaType) {
+			t.Errorf("Expected ErrUnsupportedMediaType, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package binder
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultMaxDecompressedSize is the decompressed size limit applied to
+// compressed request bodies when BindOptions.MaxDecompressedSize is zero.
+const DefaultMaxDecompressedSize = 10 << 20
+
+// ErrDecompressedBodyTooLarge is returned when a compressed request body
+// expands beyond the decompressed size limit. Errors wrapping it also wrap
+// ErrBodyTooLarge, so one check covers both limits.
+var ErrDecompressedBodyTooLarge = fmt.Errorf("decompressed %w", ErrBodyTooLarge)
+
+// errUnsupportedCoding reports a Content-Encoding that cannot be decoded
+var errUnsupportedCoding = errors.New("unsupported content coding")
+
+// decompressBody reverses the Content-Encoding codings applied to a body.
+// Codings are listed in the order they were applied, so they are removed
+// from last to first. The returned reader decompresses as it is read and
+// fails with ErrDecompressedBodyTooLarge once the size limit is passed.
+func decompressBody(body io.Reader, contentEncoding []string, opts *BindOptions) (io.Reader, error) {
+	var codings []string
+	for _, header := range contentEncoding {
+		for _, coding := range strings.Split(header, ",") {
+			coding = strings.ToLower(strings.TrimSpace(coding))
+			if coding != "" && coding != "identity" {
+				codings = append(codings, coding)
+			}
+		}
+	}
+
+	for i := len(codings) - 1; i >= 0; i-- {
+		zr, err := newDecompressor(codings[i], body)
+		if errors.Is(err, errUnsupportedCoding) && opts.RejectUnknownMediaType {
+			return nil, fmt.Errorf("%w: %w", ErrUnsupportedMediaType, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		body = &limitedReader{r: zr, remaining: opts.maxDecompressedSize()}
+	}
+	return body, nil
+}
+
+// newDecompressor returns a reader that decodes body in the given coding
+func newDecompressor(coding string, body io.Reader) (io.Reader, error) {
+	switch coding {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		// HTTP deflate is zlib-wrapped, but some clients send raw deflate
+		br := bufio.NewReader(body)
+		if header, err := br.Peek(2); err == nil && isZlibHeader(header) {
+			return zlib.NewReader(br)
+		}
+		return flate.NewReader(br), nil
+	default:
+		return nil, fmt.Errorf("%w %q", errUnsupportedCoding, coding)
+	}
+}
+
+// isZlibHeader reports whether b starts with a valid zlib stream header
+func isZlibHeader(b []byte) bool {
+	return b[0]&0x0F == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
+
+// limitedReader reads from r until more than remaining bytes have been
+// produced, then fails with ErrDecompressedBodyTooLarge. A negative
+// remaining disables the limit.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+}
+
+// Read implements io.Reader
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return l.r.Read(p)
+	}
+	if l.limit == 0 {
+		l.limit = l.remaining
+	}
+	if l.remaining == 0 {
+		// Probe for data beyond the limit
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: limit is %d bytes", ErrDecompressedBodyTooLarge, l.limit)
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,136 @@
+package binder
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func compress(t *testing.T, coding string, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch coding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		if err != nil {
+			t.Fatalf("Failed to create flate writer: %v", err)
+		}
+		w = fw
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("Failed to compress: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to compress: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBindCompressedBody(t *testing.T) {
+	payload := []byte(`{"name": "Alice", "count": 3}`)
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"Gzip", "gzip", compress(t, "gzip", payload)},
+		{"Deflate", "deflate", compress(t, "deflate", payload)},
+		{"RawDeflate", "deflate", compress(t, "raw-deflate", payload)},
+		{"Stacked", "deflate, gzip", compress(t, "gzip", compress(t, "deflate", payload))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/test", bytes.NewReader(tt.body))
+			r.Header.Set("Content-Type", "application/json")
+			r.Header.Set("Content-Encoding", tt.encoding)
+
+			var p struct {
+				Name  string `body:"name"`
+				Count int    `body:"count"`
+			}
+			if err := Bind(r, &p); err != nil {
+				t.Fatalf("Binding failed with error: %v", err)
+			}
+			if p.Name != "Alice" || p.Count != 3 {
+				t.Errorf("Unexpected result %+v", p)
+			}
+
+			// The restored body still matches its Content-Encoding
+			restored, _ := io.ReadAll(r.Body)
+			if !bytes.Equal(restored, tt.body) {
+				t.Errorf("Expected restored body to be the original compressed bytes")
+			}
+		})
+	}
+}
+
+func TestBindDecompressionLimit(t *testing.T) {
+	payload := []byte(`{"name": "` + strings.Repeat("a", 4096) + `"}`)
+	body := compress(t, "gzip", payload)
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("POST", "/test", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Content-Encoding", "gzip")
+		return r
+	}
+
+	var p struct {
+		Name string `body:"name"`
+	}
+
+	err := BindWithOptions(newRequest(), &p, BindOptions{MaxDecompressedSize: 1024})
+	if !errors.Is(err, ErrDecompressedBodyTooLarge) {
+		t.Errorf("Expected ErrDecompressedBodyTooLarge, got: %v", err)
+	}
+
+	if err := BindWithOptions(newRequest(), &p, BindOptions{MaxDecompressedSize: -1}); err != nil {
+		t.Errorf("Binding without a limit failed with error: %v", err)
+	}
+	if len(p.Name) != 4096 {
+		t.Errorf("Expected Name to be bound, got %d bytes", len(p.Name))
+	}
+}
+
+func TestBindUnsupportedContentEncoding(t *testing.T) {
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("POST", "/test?page=2", bytes.NewBufferString("not really brotli"))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Content-Encoding", "br")
+		return r
+	}
+
+	var p struct {
+		Name string `body:"name"`
+		Page int    `query:"page"`
+	}
+
+	if err := Bind(newRequest(), &p); err != nil || p.Page != 2 {
+		t.Fatalf("Unsupported codings should be ignored by default, got %+v, %v", p, err)
+	}
+
+	err := BindWithOptions(newRequest(), &p, BindOptions{RejectUnknownMediaType: true})
+	if !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType, got: %v", err)
+	}
+}
+
+func TestDecompressedBodyTooLargeIsBodyTooLarge(t *testing.T) {
+	if !errors.Is(ErrDecompressedBodyTooLarge, ErrBodyTooLarge) {
+		t.Errorf("Expected ErrDecompressedBodyTooLarge to wrap ErrBodyTooLarge")
+	}
+}
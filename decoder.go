@@ -0,0 +1,204 @@
+package binder
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedMediaType is returned when BindOptions.RejectUnknownMediaType
+// is set and no decoder is registered for the request's Content-Type.
+// Handlers will usually map it to 415 Unsupported Media Type.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// Decoder decodes a request body into a map from field names to values.
+//
+// Values should use the same shapes as encoding/json produces when decoding
+// into interface{}: nested objects as map[string]interface{}, lists as
+// []interface{} and scalars as string, float64 or bool.
+type Decoder interface {
+	Decode(r io.Reader) (map[string]interface{}, error)
+}
+
+// DecoderFunc adapts an ordinary function to the Decoder interface.
+type DecoderFunc func(r io.Reader) (map[string]interface{}, error)
+
+// Decode calls f(r).
+func (f DecoderFunc) Decode(r io.Reader) (map[string]interface{}, error) {
+	return f(r)
+}
+
+// Registry of body decoders keyed by media type. The +json and +xml
+// structured syntax suffixes (RFC 6839) cover vendor and standard types such
+// as application/vnd.api+json, application/merge-patch+json and
+// application/problem+json.
+var decoders = map[string]Decoder{
+	"application/json":                  jsonDecoder{},
+	"application/*+json":                jsonDecoder{},
+	"application/xml":                   xmlDecoder{},
+	"application/*+xml":                 xmlDecoder{},
+	"text/xml":                          xmlDecoder{},
+	"application/x-www-form-urlencoded": formDecoder{},
+}
+var decodersMutex sync.RWMutex
+
+// RegisterDecoder registers the decoder used for request bodies of the given
+// media type, replacing any existing registration for it.
+//
+// Besides exact media types such as "application/cbor", the media type may
+// be a pattern:
+//
+//   - "application/*+json" matches any application type with the +json suffix
+//   - "application/*" matches any application type
+//   - "*/*" matches every type
+//
+// Exact matches take precedence over suffix patterns, which take precedence
+// over type wildcards.
+//
+// Example:
+//
+//	binder.RegisterDecoder("application/yaml", binder.DecoderFunc(func(r io.Reader) (map[string]interface{}, error) {
+//	    var m map[string]interface{}
+//	    err := yaml.NewDecoder(r).Decode(&m)
+//	    return m, err
+//	}))
+func RegisterDecoder(mediaType string, d Decoder) {
+	decodersMutex.Lock()
+	defer decodersMutex.Unlock()
+	decoders[strings.ToLower(mediaType)] = d
+}
+
+// charsetDecoder is implemented by built-in decoders that apply the
+// Content-Type charset parameter themselves instead of receiving a body that
+// has already been converted to UTF-8
+type charsetDecoder interface {
+	decodeCharset(r io.Reader, label string) (map[string]interface{}, error)
+}
+
+// valueDecoder is implemented by built-in decoders that accept bodies of any
+// top-level type, such as JSON arrays, and enforce the structural limits in
+// BindOptions while decoding, before allocating
+type valueDecoder interface {
+	decodeValue(r io.Reader, opts *BindOptions) (interface{}, error)
+}
+
+// isJSONMediaType reports whether a media type is application/json or has
+// the +json suffix, as application/merge-patch+json does
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// lookupDecoder finds the decoder registered for a media type
+func lookupDecoder(mediaType string) (Decoder, bool) {
+	decodersMutex.RLock()
+	defer decodersMutex.RUnlock()
+
+	if d, ok := decoders[mediaType]; ok {
+		return d, true
+	}
+
+	typ, sub, ok := strings.Cut(mediaType, "/")
+	if !ok {
+		return nil, false
+	}
+	if i := strings.LastIndex(sub, "+"); i != -1 {
+		if d, ok := decoders[typ+"/*"+sub[i:]]; ok {
+			return d, true
+		}
+	}
+	if d, ok := decoders[typ+"/*"]; ok {
+		return d, true
+	}
+	d, ok := decoders["*/*"]
+	return d, ok
+}
+
+// jsonDecoder is the Decoder for JSON media types
+type jsonDecoder struct{}
+
+// Decode decodes a JSON object body
+func (jsonDecoder) Decode(r io.Reader) (map[string]interface{}, error) {
+	var reqBody map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&reqBody); err != nil {
+		return nil, err
+	}
+	return reqBody, nil
+}
+
+// decodeValue decodes a JSON body of any top-level type. With structural
+// limits set it decodes token by token so that oversized structures are
+// rejected before they are allocated.
+func (jsonDecoder) decodeValue(r io.Reader, opts *BindOptions) (interface{}, error) {
+	dec := json.NewDecoder(r)
+	if opts.hasStructuralLimits() {
+		return decodeJSONValue(dec, opts, 1)
+	}
+	var v interface{}
+	err := dec.Decode(&v)
+	return v, err
+}
+
+// formDecoder is the Decoder for application/x-www-form-urlencoded bodies.
+// Keys with a single value map to a string, repeated keys to a list of strings.
+// Keys in bracket or dot notation also build nested maps and lists.
+type formDecoder struct{}
+
+// Decode decodes a UTF-8 form body
+func (d formDecoder) Decode(r io.Reader) (map[string]interface{}, error) {
+	return d.decodeCharset(r, "")
+}
+
+// decodeCharset decodes a form body in the given charset. Browsers
+// percent-encode bytes in the form's charset, so keys and values are
+// converted after percent-decoding rather than before. UTF-16 is the
+// exception: its ASCII delimiters are two bytes wide, so the whole body is
+// converted first.
+func (formDecoder) decodeCharset(r io.Reader, label string) (map[string]interface{}, error) {
+	cs, _ := lookupCharset(label)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	switch cs {
+	case charsetUTF16, charsetUTF16LE, charsetUTF16BE:
+		if data, err = cs.toUTF8(data); err != nil {
+			return nil, err
+		}
+		cs = charsetUTF8
+	}
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	convert := func(s string) (string, error) {
+		if cs == charsetUTF8 {
+			return s, nil
+		}
+		b, err := cs.toUTF8([]byte(s))
+		return string(b), err
+	}
+
+	reqBody := make(map[string]interface{}, len(form))
+	for k, v := range form {
+		key, err := convert(k)
+		if err != nil {
+			return nil, err
+		}
+		list := make([]interface{}, len(v))
+		for i := range v {
+			if list[i], err = convert(v[i]); err != nil {
+				return nil, err
+			}
+		}
+		if len(list) == 1 {
+			reqBody[key] = list[0]
+		} else {
+			reqBody[key] = list
+		}
+	}
+	return expandKeys(reqBody), nil
+}
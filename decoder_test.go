@@ -0,0 +1,176 @@
+package binder
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// decodeLines is a toy decoder for key=value lines used to test the registry
+func decodeLines(r io.Reader) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		k, v, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			return nil, errors.New("malformed line")
+		}
+		m[k] = v
+	}
+	return m, scanner.Err()
+}
+
+// registerTestDecoder registers d for the duration of the test
+func registerTestDecoder(t *testing.T, mediaType string, d Decoder) {
+	t.Helper()
+	RegisterDecoder(mediaType, d)
+	t.Cleanup(func() {
+		decodersMutex.Lock()
+		delete(decoders, mediaType)
+		decodersMutex.Unlock()
+	})
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	registerTestDecoder(t, "text/x-lines", DecoderFunc(decodeLines))
+
+	r := httptest.NewRequest("POST", "/test", strings.NewReader("name=Alice\ncount=3"))
+	r.Header.Set("Content-Type", "text/x-lines; charset=utf-8")
+
+	type params struct {
+		Name  string `body:"name"`
+		Count int    `body:"count"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.Name != "Alice" || p.Count != 3 {
+		t.Errorf("Unexpected result %+v", p)
+	}
+}
+
+func TestLookupDecoderPatterns(t *testing.T) {
+	exact := DecoderFunc(decodeLines)
+	suffix := DecoderFunc(jsonDecoder{}.Decode)
+	wildcard := DecoderFunc(func(io.Reader) (map[string]interface{}, error) {
+		return map[string]interface{}{}, nil
+	})
+
+	registerTestDecoder(t, "application/vnd.test.exact+lines", exact)
+	registerTestDecoder(t, "application/*+lines", suffix)
+	registerTestDecoder(t, "image/*", wildcard)
+
+	tests := []struct {
+		mediaType string
+		want      Decoder
+	}{
+		{"application/vnd.test.exact+lines", exact},
+		{"application/vnd.other+lines", suffix},
+		{"image/x-test", wildcard},
+	}
+
+	for _, tt := range tests {
+		d, ok := lookupDecoder(tt.mediaType)
+		if !ok {
+			t.Errorf("lookupDecoder(%q) found no decoder", tt.mediaType)
+			continue
+		}
+		if reflect.ValueOf(d).Pointer() != reflect.ValueOf(tt.want).Pointer() {
+			t.Errorf("lookupDecoder(%q) returned the wrong decoder", tt.mediaType)
+		}
+	}
+
+	if _, ok := lookupDecoder("video/x-test"); ok {
+		t.Errorf("lookupDecoder(%q) should find no decoder", "video/x-test")
+	}
+}
+
+func TestRejectUnknownMediaType(t *testing.T) {
+	type params struct {
+		Name string `body:"name"`
+		Page int    `query:"page"`
+	}
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest("POST", "/test?page=2", strings.NewReader("name: Alice"))
+		r.Header.Set("Content-Type", "application/x-unknown")
+		return r
+	}
+
+	var p params
+	if err := Bind(newRequest(), &p); err != nil {
+		t.Fatalf("Unknown media types should be ignored by default, got: %v", err)
+	}
+	if p.Page != 2 || p.Name != "" {
+		t.Errorf("Unexpected result %+v", p)
+	}
+
+	err := BindWithOptions(newRequest(), &p, BindOptions{RejectUnknownMediaType: true})
+	if !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("Expected ErrUnsupportedMediaType, got: %v", err)
+	}
+}
+
+func TestBindFormRepeatedValues(t *testing.T) {
+	form := url.Values{}
+	form.Add("tag", "a")
+	form.Add("tag", "b")
+	form.Add("id", "1")
+	form.Add("id", "2")
+
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	type params struct {
+		Tags []string `body:"tag"`
+		IDs  []int    `body:"id"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if !reflect.DeepEqual(p.Tags, []string{"a", "b"}) || !reflect.DeepEqual(p.IDs, []int{1, 2}) {
+		t.Errorf("Unexpected result %+v", p)
+	}
+}
+
+func TestBindStructuredSyntaxSuffix(t *testing.T) {
+	type params struct {
+		Name string `body:"name"`
+	}
+
+	tests := []struct {
+		contentType string
+		payload     string
+	}{
+		{"application/vnd.api+json", `{"name": "api"}`},
+		{"application/merge-patch+json", `{"name": "patch"}`},
+		{"application/problem+json; charset=utf-8", `{"name": "problem"}`},
+		{"application/atom+xml", `<feed><name>atom</name></feed>`},
+		{"application/vnd.partner.order+xml", `<order><name>partner</name></order>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/test", strings.NewReader(tt.payload))
+			r.Header.Set("Content-Type", tt.contentType)
+
+			var p params
+			if err := BindWithOptions(r, &p, BindOptions{RejectUnknownMediaType: true}); err != nil {
+				t.Fatalf("Binding failed with error: %v", err)
+			}
+			if p.Name == "" {
+				t.Errorf("Expected Name to be bound from %s body", tt.contentType)
+			}
+		})
+	}
+}
@@ -57,12 +57,25 @@ func (r CreateUserRequest) Validate() error {
 	return nil
 }
 
+// UpdateUserRequest uses binder.Optional so that fields left out of the
+// request are left unchanged, while "tags": null clears the tags
 type UpdateUserRequest struct {
-	ID     int      `path:"id"`
-	Name   string   `body:"name,omitempty"`
-	Email  string   `body:"email,omitempty"`
-	Active *bool    `body:"active,omitempty"`
-	Tags   []string `body:"tags,omitempty"`
+	ID     int                       `path:"id"`
+	Name   binder.Optional[string]   `body:"name"`
+	Email  binder.Optional[string]   `body:"email"`
+	Active binder.Optional[bool]     `body:"active"`
+	Tags   binder.Optional[[]string] `body:"tags"`
+}
+
+// Validate implements the binder.Validator interface
+func (r UpdateUserRequest) Validate() error {
+	if r.Name.IsNull() || r.Name.Set && r.Name.Value == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if r.Email.IsNull() || r.Email.Set && r.Email.Value == "" {
+		return fmt.Errorf("email cannot be empty")
+	}
+	return nil
 }
 
 // HTTP Handlers
@@ -159,18 +172,18 @@ func updateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Update fields if provided (omitempty allows partial updates)
-	if req.Name != "" {
-		user.Name = req.Name
+	// Update only the fields present in the request
+	if name, ok := req.Name.Get(); ok {
+		user.Name = name
 	}
-	if req.Email != "" {
-		user.Email = req.Email
+	if email, ok := req.Email.Get(); ok {
+		user.Email = email
 	}
-	if req.Active != nil {
-		user.Active = *req.Active
+	if active, ok := req.Active.Get(); ok {
+		user.Active = active
 	}
-	if req.Tags != nil {
-		user.Tags = req.Tags
+	if req.Tags.Set {
+		user.Tags = req.Tags.Value // nil when sent as null
 	}
 
 	users[req.ID] = user
@@ -230,6 +243,7 @@ func main() {
 	mux.HandleFunc("GET /users", listUsers)          // Query parameters + cookies
 	mux.HandleFunc("POST /users", createUser)        // JSON body + validation
 	mux.HandleFunc("PUT /users/{id}", updateUser)    // Path + body (partial updates)
+	mux.HandleFunc("PATCH /users/{id}", updateUser)  // Same, with PATCH semantics
 	mux.HandleFunc("DELETE /users/{id}", deleteUser) // Path parameter
 
 	// Wrap with demo middleware
@@ -242,6 +256,7 @@ func main() {
 	fmt.Println("  GET    http://localhost:8080/users?active=true&limit=5")
 	fmt.Println("  POST   http://localhost:8080/users")
 	fmt.Println("  PUT    http://localhost:8080/users/1")
+	fmt.Println("  PATCH  http://localhost:8080/users/1")
 	fmt.Println("  DELETE http://localhost:8080/users/1")
 	fmt.Println()
 	fmt.Println("See example/README.md for detailed usage instructions")
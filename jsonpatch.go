@@ -0,0 +1,758 @@
+package binder
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Errors wrapped by PatchError, describing why a JSON Patch operation failed
+var (
+	ErrInvalidPatch    = errors.New("invalid patch operation")
+	ErrPathNotFound    = errors.New("path not found")
+	ErrPatchTestFailed = errors.New("test failed")
+)
+
+// PatchError reports the JSON Patch operation that failed. Its Err wraps
+// ErrInvalidPatch, ErrPathNotFound, ErrPatchTestFailed or a conversion error.
+type PatchError struct {
+	Index int    // position of the operation in the patch
+	Op    string // operation name, such as "replace"
+	Path  string // JSON Pointer the operation targets
+	Err   error
+}
+
+// Error implements the error interface
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("patch operation %d (%s %s): %v", e.Index, e.Op, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// patchOperation is one decoded JSON Patch operation
+type patchOperation struct {
+	op       string
+	path     []string
+	from     []string
+	value    interface{}
+	hasValue bool
+}
+
+// ApplyJSONPatch applies a JSON Patch (RFC 6902) request body, an array of
+// add, remove, replace, move, copy and test operations, to an existing
+// value. JSON Pointers are resolved against the same body, json and xml tag
+// names used for binding, descending through nested structs, pointers,
+// slices and maps, and values are converted to field types as Bind does.
+//
+// Operations apply in order and the patch is atomic: if any operation
+// fails the target is left unchanged and the returned *PatchError names the
+// failing operation. If the target implements Validator, Validate is called
+// on the patched value.
+//
+// Example:
+//
+//	// [{"op": "test", "path": "/version", "value": 3},
+//	//  {"op": "replace", "path": "/address/city", "value": "Paris"},
+//	//  {"op": "add", "path": "/tags/-", "value": "vip"}]
+//	var patchErr *binder.PatchError
+//	if err := binder.ApplyJSONPatch(r, &user); errors.As(err, &patchErr) {
+//	    // Report patchErr.Index and patchErr.Path
+//	}
+func ApplyJSONPatch(r *http.Request, i interface{}) error {
+	return applyJSONPatch(r, i, &defaultOptions)
+}
+
+// ApplyJSONPatchWithOptions behaves like ApplyJSONPatch but applies the
+// given options to this call
+func ApplyJSONPatchWithOptions(r *http.Request, i interface{}, opts BindOptions) error {
+	return applyJSONPatch(r, i, &opts)
+}
+
+// applyJSONPatch implements ApplyJSONPatchWithOptions without copying opts
+func applyJSONPatch(r *http.Request, i interface{}, opts *BindOptions) error {
+	val := reflect.ValueOf(i)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return errors.New("json patch target must be a non-nil pointer")
+	}
+
+	doc, err := decodeJSONBody(r, opts)
+	if err != nil {
+		return err
+	}
+	ops, err := parsePatch(doc)
+	if err != nil {
+		return err
+	}
+
+	// Work on a copy so a failed operation leaves the target unchanged
+	target := reflect.New(val.Elem().Type()).Elem()
+	target.Set(deepCopy(val.Elem()))
+	for index, op := range ops {
+		if err := applyOperation(target, op, opts); err != nil {
+			return &PatchError{Index: index, Op: op.op, Path: formatPointer(op.path), Err: err}
+		}
+	}
+	val.Elem().Set(target)
+
+	if validator, ok := i.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// parsePatch checks the shape of a decoded JSON Patch document
+func parsePatch(doc interface{}) ([]patchOperation, error) {
+	list, ok := doc.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: json patch must be a JSON array, got %T", ErrInvalidPatch, doc)
+	}
+
+	ops := make([]patchOperation, len(list))
+	for index, elem := range list {
+		m, ok := elem.(map[string]interface{})
+		if !ok {
+			return nil, &PatchError{Index: index, Err: fmt.Errorf("%w: operation must be an object", ErrInvalidPatch)}
+		}
+		op, _ := m["op"].(string)
+		path, _ := m["path"].(string)
+		patchErr := func(format string, args ...interface{}) error {
+			return &PatchError{Index: index, Op: op, Path: path, Err: fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidPatch}, args...)...)}
+		}
+
+		tokens, err := parsePointer(path)
+		if _, isString := m["path"].(string); !isString || err != nil {
+			return nil, patchErr("invalid path %q", path)
+		}
+		ops[index] = patchOperation{op: op, path: tokens}
+		ops[index].value, ops[index].hasValue = m["value"]
+
+		switch op {
+		case "add", "replace", "test":
+			if !ops[index].hasValue {
+				return nil, patchErr("missing value")
+			}
+		case "move", "copy":
+			from, isString := m["from"].(string)
+			if ops[index].from, err = parsePointer(from); !isString || err != nil {
+				return nil, patchErr("invalid from %q", from)
+			}
+		case "remove":
+		default:
+			return nil, patchErr("unknown op %q", op)
+		}
+	}
+	return ops, nil
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into unescaped tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("pointer %q does not start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// formatPointer joins tokens back into a JSON Pointer
+func formatPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return b.String()
+}
+
+// applyOperation applies one operation to the addressable root value
+func applyOperation(root reflect.Value, op patchOperation, opts *BindOptions) error {
+	remove := func(parent reflect.Value, token string) error {
+		return removeMember(parent, token, opts)
+	}
+
+	switch op.op {
+	case "add":
+		return setAt(root, op.path, op.value, true, opts)
+
+	case "replace":
+		return setAt(root, op.path, op.value, false, opts)
+
+	case "remove":
+		if len(op.path) == 0 {
+			return fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+		}
+		return walkPointer(root, op.path, remove, opts)
+
+	case "test":
+		actual, err := getAt(root, op.path, opts)
+		if err != nil {
+			return err
+		}
+		if !jsonEqual(actual, op.value, opts) {
+			return fmt.Errorf("%w: value is %v", ErrPatchTestFailed, actual.Interface())
+		}
+		return nil
+
+	case "move", "copy":
+		if op.op == "move" && len(op.from) < len(op.path) && isPointerPrefix(op.from, op.path) {
+			return fmt.Errorf("%w: cannot move a value into one of its children", ErrInvalidPatch)
+		}
+		value, err := getAt(root, op.from, opts)
+		if err != nil {
+			return fmt.Errorf("from %s: %w", formatPointer(op.from), err)
+		}
+		if op.op == "move" && isPointerPrefix(op.path, op.from) && len(op.path) == len(op.from) {
+			return nil // moving a value onto itself changes nothing
+		}
+		// Detach the value from the document before its source is removed
+		detached := reflect.New(value.Type()).Elem()
+		detached.Set(deepCopy(value))
+		value = detached
+		if op.op == "move" {
+			if err := walkPointer(root, op.from, remove, opts); err != nil {
+				return err
+			}
+		}
+		if len(op.path) == 0 {
+			return assignValue(root, value, opts)
+		}
+		return walkPointer(root, op.path, func(parent reflect.Value, token string) error {
+			return setMember(parent, token, true, func(dst reflect.Value) error {
+				return assignValue(dst, value, opts)
+			}, opts)
+		}, opts)
+	}
+	return fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, op.op)
+}
+
+// isPointerPrefix reports whether prefix names path or one of its ancestors
+func isPointerPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// setAt converts value into the location named by path. With add set, a
+// new slice element or map key may be created; otherwise it must exist.
+func setAt(root reflect.Value, path []string, value interface{}, add bool, opts *BindOptions) error {
+	convert := func(dst reflect.Value) error {
+		fresh := reflect.New(dst.Type()).Elem()
+		if err := setField(fresh, value, "", opts); err != nil {
+			return err
+		}
+		dst.Set(fresh)
+		return nil
+	}
+	if len(path) == 0 {
+		return convert(root)
+	}
+	return walkPointer(root, path, func(parent reflect.Value, token string) error {
+		return setMember(parent, token, add, convert, opts)
+	}, opts)
+}
+
+// getAt returns the value at path
+func getAt(root reflect.Value, path []string, opts *BindOptions) (reflect.Value, error) {
+	if len(path) == 0 {
+		return root, nil
+	}
+	var result reflect.Value
+	err := walkPointer(root, path, func(parent reflect.Value, token string) error {
+		v, err := member(parent, token, opts)
+		result = v
+		return err
+	}, opts)
+	return result, err
+}
+
+// walkPointer descends from v through all but the last token of path and
+// calls fn with the container and last token. Map values and interface
+// contents are not addressable, so they are copied and written back.
+func walkPointer(v reflect.Value, path []string, fn func(parent reflect.Value, token string) error, opts *BindOptions) error {
+	v, err := indirectContainer(v)
+	if err != nil {
+		return err
+	}
+	if len(path) == 1 {
+		return fn(v, path[0])
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		key, elem, err := mapMember(v, path[0])
+		if err != nil {
+			return err
+		}
+		cp := reflect.New(elem.Type()).Elem()
+		cp.Set(elem)
+		if err := walkPointer(cp, path[1:], fn, opts); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, cp)
+		return nil
+
+	case reflect.Interface:
+		cp := reflect.New(v.Elem().Type()).Elem()
+		cp.Set(v.Elem())
+		if err := walkPointer(cp, path, fn, opts); err != nil {
+			return err
+		}
+		v.Set(cp)
+		return nil
+	}
+
+	child, err := member(v, path[0], opts)
+	if err != nil {
+		return err
+	}
+	return walkPointer(child, path[1:], fn, opts)
+}
+
+// indirectContainer follows pointers to the value they point at
+func indirectContainer(v reflect.Value) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return v, ErrPathNotFound
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Interface && v.IsNil() {
+		return v, ErrPathNotFound
+	}
+	return v, nil
+}
+
+// member returns the struct field, slice element or map value named by token
+func member(parent reflect.Value, token string, opts *BindOptions) (reflect.Value, error) {
+	parent, err := indirectContainer(parent)
+	if err != nil {
+		return parent, err
+	}
+
+	switch parent.Kind() {
+	case reflect.Struct:
+		if i, ok := structFieldByKey(parent.Type(), token, opts); ok {
+			return parent.Field(i), nil
+		}
+	case reflect.Slice:
+		if i, err := sliceIndex(token, parent.Len(), false); err == nil {
+			return parent.Index(i), nil
+		}
+	case reflect.Map:
+		_, elem, err := mapMember(parent, token)
+		return elem, err
+	case reflect.Interface:
+		return member(parent.Elem(), token, opts)
+	}
+	return reflect.Value{}, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+}
+
+// setMember sets the member of parent named by token using set, which is
+// given an addressable value of the member's type. With add set, a slice
+// element is inserted and a map key created; otherwise they must exist.
+func setMember(parent reflect.Value, token string, add bool, set func(reflect.Value) error, opts *BindOptions) error {
+	parent, err := indirectContainer(parent)
+	if err != nil {
+		return err
+	}
+
+	switch parent.Kind() {
+	case reflect.Struct:
+		i, ok := structFieldByKey(parent.Type(), token, opts)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrPathNotFound, token)
+		}
+		return set(parent.Field(i))
+
+	case reflect.Slice:
+		i, err := sliceIndex(token, parent.Len(), add)
+		if err != nil {
+			return err
+		}
+		if !add {
+			return set(parent.Index(i))
+		}
+		if !parent.CanSet() {
+			return fmt.Errorf("%w: cannot grow %s", ErrPathNotFound, parent.Type())
+		}
+		elem := reflect.New(parent.Type().Elem()).Elem()
+		if err := set(elem); err != nil {
+			return err
+		}
+		grown := reflect.MakeSlice(parent.Type(), 0, parent.Len()+1)
+		grown = reflect.AppendSlice(grown, parent.Slice(0, i))
+		grown = reflect.Append(grown, elem)
+		grown = reflect.AppendSlice(grown, parent.Slice(i, parent.Len()))
+		parent.Set(grown)
+		return nil
+
+	case reflect.Map:
+		key := reflect.ValueOf(token).Convert(parent.Type().Key())
+		if !add && !parent.MapIndex(key).IsValid() {
+			return fmt.Errorf("%w: %q", ErrPathNotFound, token)
+		}
+		if parent.IsNil() {
+			parent.Set(reflect.MakeMap(parent.Type()))
+		}
+		elem := reflect.New(parent.Type().Elem()).Elem()
+		if err := set(elem); err != nil {
+			return err
+		}
+		parent.SetMapIndex(key, elem)
+		return nil
+
+	case reflect.Interface:
+		cp := reflect.New(parent.Elem().Type()).Elem()
+		cp.Set(parent.Elem())
+		if err := setMember(cp, token, add, set, opts); err != nil {
+			return err
+		}
+		parent.Set(cp)
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrPathNotFound, token)
+}
+
+// removeMember removes the member of parent named by token. Struct fields
+// cannot be removed, so they are reset to their zero value.
+func removeMember(parent reflect.Value, token string, opts *BindOptions) error {
+	parent, err := indirectContainer(parent)
+	if err != nil {
+		return err
+	}
+
+	switch parent.Kind() {
+	case reflect.Struct:
+		i, ok := structFieldByKey(parent.Type(), token, opts)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrPathNotFound, token)
+		}
+		parent.Field(i).Set(reflect.Zero(parent.Field(i).Type()))
+		return nil
+
+	case reflect.Slice:
+		i, err := sliceIndex(token, parent.Len(), false)
+		if err != nil {
+			return err
+		}
+		shrunk := reflect.MakeSlice(parent.Type(), 0, parent.Len()-1)
+		shrunk = reflect.AppendSlice(shrunk, parent.Slice(0, i))
+		shrunk = reflect.AppendSlice(shrunk, parent.Slice(i+1, parent.Len()))
+		parent.Set(shrunk)
+		return nil
+
+	case reflect.Map:
+		key, _, err := mapMember(parent, token)
+		if err != nil {
+			return err
+		}
+		parent.SetMapIndex(key, reflect.Value{})
+		return nil
+
+	case reflect.Interface:
+		cp := reflect.New(parent.Elem().Type()).Elem()
+		cp.Set(parent.Elem())
+		if err := removeMember(cp, token, opts); err != nil {
+			return err
+		}
+		parent.Set(cp)
+		return nil
+	}
+	return fmt.Errorf("%w: %q", ErrPathNotFound, token)
+}
+
+// mapMember returns the key and value of an existing map entry
+func mapMember(m reflect.Value, token string) (reflect.Value, reflect.Value, error) {
+	if m.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("%w: map keys are not strings", ErrPathNotFound)
+	}
+	key := reflect.ValueOf(token).Convert(m.Type().Key())
+	elem := m.MapIndex(key)
+	if !elem.IsValid() {
+		return key, elem, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+	}
+	return key, elem, nil
+}
+
+// sliceIndex parses a slice index token. When adding, "-" and the length
+// itself name the position after the last element.
+func sliceIndex(token string, length int, add bool) (int, error) {
+	if add && token == "-" {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || token != strconv.Itoa(i) {
+		return 0, fmt.Errorf("%w: invalid index %q", ErrPathNotFound, token)
+	}
+	if i > length || i == length && !add {
+		return 0, fmt.Errorf("%w: index %d out of range", ErrPathNotFound, i)
+	}
+	return i, nil
+}
+
+// structFieldByKey finds the exported field a body key binds to, using the
+// same tags as lookupBodyField. JSON Pointers name members exactly, whatever
+// the KeyMatching.
+func structFieldByKey(typ reflect.Type, key string, opts *BindOptions) (int, bool) {
+	for i, field := range structFields(typ, opts.Tags) {
+		if !field.IsExported() {
+			continue
+		}
+		if tag := field.Tag.Get(bind); tag != "" {
+			// Any body or query entry of a chain names the field
+			refs, _ := parseBindTag(tag, field.Name, opts.Naming)
+			for _, ref := range refs {
+				if (ref.Source == body || ref.Source == query) && ref.Name == key {
+					return i, true
+				}
+			}
+			continue
+		}
+		if patchKey(field, opts) == key {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// jsonEqual reports whether v equals the decoded JSON value want, as RFC
+// 6902 compares values for the test operation: the JSON types must match,
+// so "0" does not equal 0 and 1 does not equal true. Structs compare as
+// objects keyed as binding reads them. Times and values with their own text
+// decoding are compared after converting want, as they have no JSON type
+// of their own.
+func jsonEqual(v reflect.Value, want interface{}, opts *BindOptions) bool {
+	if isOptionalType(v.Type()) {
+		if !v.FieldByName("Set").Bool() || v.FieldByName("Null").Bool() {
+			return want == nil
+		}
+		return jsonEqual(v.FieldByName("Value"), want, opts)
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return want == nil
+		}
+		return jsonEqual(v.Elem(), want, opts)
+	case reflect.Slice, reflect.Map:
+		// nil encodes as null, so only null matches it
+		if v.IsNil() {
+			return want == nil
+		}
+	}
+	// null never matches a zero value such as "" or 0
+	if want == nil {
+		return false
+	}
+	if isPatchLeaf(v.Type()) {
+		expected := reflect.New(v.Type()).Elem()
+		if err := setField(expected, want, "", opts); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(expected.Interface(), v.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, ok := want.(bool)
+		return ok && b == v.Bool()
+	case reflect.String:
+		s, ok := want.(string)
+		return ok && s == v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := want.(float64)
+		return ok && f == float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f, ok := want.(float64)
+		return ok && f == float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f, ok := want.(float64)
+		return ok && f == v.Float()
+	case reflect.Slice, reflect.Array:
+		list, ok := want.([]interface{})
+		if !ok || len(list) != v.Len() {
+			return false
+		}
+		for i := range list {
+			if !jsonEqual(v.Index(i), list[i], opts) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		object, ok := want.(map[string]interface{})
+		if !ok || len(object) != v.Len() || v.Type().Key().Kind() != reflect.String {
+			return false
+		}
+		for k, member := range object {
+			elem := v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key()))
+			if !elem.IsValid() || !jsonEqual(elem, member, opts) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		object, ok := want.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		matched := 0
+		for i, field := range structFields(v.Type(), opts.Tags) {
+			key := patchKey(field, opts)
+			if key == "" || !field.IsExported() {
+				continue
+			}
+			member, ok := object[key]
+			if !ok || !jsonEqual(v.Field(i), member, opts) {
+				return false
+			}
+			matched++
+		}
+		return matched == len(object)
+	}
+	return false
+}
+
+// isPatchLeaf reports whether values of t are compared after converting
+// the JSON value, as types without a JSON type of their own are
+func isPatchLeaf(t reflect.Type) bool {
+	if t == timeType || t == durationType || t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+		return true
+	}
+	textUnmarshaler := reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	return reflect.PointerTo(t).Implements(textUnmarshaler)
+}
+
+// patchKey returns the key a JSON Pointer names a struct field by, the
+// first body or query entry of a bind chain, or "" if no single key
+// reaches the field
+func patchKey(field reflect.StructField, opts *BindOptions) string {
+	if tag := field.Tag.Get(bind); tag != "" {
+		refs, _ := parseBindTag(tag, field.Name, opts.Naming)
+		for _, ref := range refs {
+			if ref.Source == body || ref.Source == query {
+				return ref.Name
+			}
+		}
+		return ""
+	}
+	if tag := field.Tag.Get(body); isWholeBodyTag(tag) || isRawBodyTag(tag) {
+		return ""
+	}
+	if name := tagName(field.Tag.Get(body)); name != "" {
+		return name
+	}
+	if name := tagName(field.Tag.Get(jjson)); name != "" {
+		return name
+	}
+	if tag := field.Tag.Get(xxml); tag != "" {
+		name, flags, _ := strings.Cut(tag, ",")
+		for _, flag := range strings.Split(flags, ",") {
+			switch flag {
+			case "chardata":
+				return xmlText
+			case "innerxml", "comment", "any":
+				return ""
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if name == "-" || strings.Contains(name, ">") {
+			return ""
+		}
+		return name
+	}
+	return tagName(field.Tag.Get(query))
+}
+
+// assignValue sets dst from a value taken from elsewhere in the document,
+// converting between types with setField when they differ
+func assignValue(dst, src reflect.Value, opts *BindOptions) error {
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	if src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return assignValue(dst, src.Elem(), opts)
+	}
+	return setField(dst, src.Interface(), "", opts)
+}
+
+// deepCopy returns a copy of v that shares no slices, maps or pointers
+// with it, so that patching the copy leaves v unchanged
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(deepCopy(v.Elem()))
+		return cp
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return cp
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return cp
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(deepCopy(v.Elem()))
+		return cp
+
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return cp
+	}
+	return v
+}
@@ -0,0 +1,209 @@
+package binder
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type patchItem struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+type patchDoc struct {
+	Version int                    `json:"version"`
+	Name    string                 `body:"name"`
+	Nick    *string                `json:"nick"`
+	Tags    []string               `json:"tags"`
+	Items   []patchItem            `json:"items"`
+	Address *mergeAddress          `json:"address"`
+	Labels  map[string]int         `json:"labels"`
+	Meta    map[string]interface{} `json:"meta"`
+	Odd     string                 `json:"a/b~c"`
+	Flag    bool                   `json:"flag"`
+}
+
+func newPatchDoc() patchDoc {
+	return patchDoc{
+		Version: 3,
+		Name:    "Alice",
+		Tags:    []string{"a", "b"},
+		Items:   []patchItem{{"x", 1}, {"y", 2}},
+		Address: &mergeAddress{Street: "Main St", City: "Springfield"},
+		Labels:  map[string]int{"one": 1},
+		Meta:    map[string]interface{}{"prefs": map[string]interface{}{"theme": "dark"}},
+		Flag:    true,
+	}
+}
+
+func applyPatch(t *testing.T, doc *patchDoc, patch string) error {
+	t.Helper()
+	r := httptest.NewRequest("PATCH", "/docs/1", strings.NewReader(patch))
+	r.Header.Set("Content-Type", "application/json-patch+json")
+	return ApplyJSONPatch(r, doc)
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	doc := newPatchDoc()
+	err := applyPatch(t, &doc, `[
+		{"op": "test", "path": "/version", "value": 3},
+		{"op": "test", "path": "/nick", "value": null},
+		{"op": "test", "path": "/flag", "value": true},
+		{"op": "test", "path": "/items", "value": [{"sku": "x", "qty": 1}, {"sku": "y", "qty": 2}]},
+		{"op": "test", "path": "/labels", "value": {"one": 1}},
+		{"op": "replace", "path": "/version", "value": "4"},
+		{"op": "replace", "path": "/name", "value": "Alicia"},
+		{"op": "add", "path": "/nick", "value": "Al"},
+		{"op": "add", "path": "/tags/-", "value": "c"},
+		{"op": "add", "path": "/tags/0", "value": "z"},
+		{"op": "remove", "path": "/tags/2"},
+		{"op": "replace", "path": "/items/1/qty", "value": 5},
+		{"op": "add", "path": "/items/-", "value": {"sku": "w", "qty": 9}},
+		{"op": "replace", "path": "/address/city", "value": "Shelbyville"},
+		{"op": "add", "path": "/labels/two", "value": 2},
+		{"op": "remove", "path": "/labels/one"},
+		{"op": "add", "path": "/meta/prefs/lang", "value": "en"},
+		{"op": "copy", "from": "/items/0/sku", "path": "/a~1b~0c"},
+		{"op": "test", "path": "/a~1b~0c", "value": "x"}
+	]`)
+	if err != nil {
+		t.Fatalf("ApplyJSONPatch failed with error: %v", err)
+	}
+
+	if doc.Version != 4 || doc.Name != "Alicia" {
+		t.Errorf("Unexpected version %d and name %q", doc.Version, doc.Name)
+	}
+	if doc.Nick == nil || *doc.Nick != "Al" {
+		t.Errorf("Expected Nick to be 'Al', got %v", doc.Nick)
+	}
+	if !reflect.DeepEqual(doc.Tags, []string{"z", "a", "c"}) {
+		t.Errorf("Unexpected tags %v", doc.Tags)
+	}
+	wantItems := []patchItem{{"x", 1}, {"y", 5}, {"w", 9}}
+	if !reflect.DeepEqual(doc.Items, wantItems) {
+		t.Errorf("Expected items %v, got %v", wantItems, doc.Items)
+	}
+	if doc.Address.City != "Shelbyville" || doc.Address.Street != "Main St" {
+		t.Errorf("Unexpected address %+v", doc.Address)
+	}
+	if !reflect.DeepEqual(doc.Labels, map[string]int{"two": 2}) {
+		t.Errorf("Unexpected labels %v", doc.Labels)
+	}
+	wantMeta := map[string]interface{}{"prefs": map[string]interface{}{"theme": "dark", "lang": "en"}}
+	if !reflect.DeepEqual(doc.Meta, wantMeta) {
+		t.Errorf("Unexpected meta %v", doc.Meta)
+	}
+	if doc.Odd != "x" {
+		t.Errorf("Expected escaped pointer to reach Odd, got %q", doc.Odd)
+	}
+}
+
+func TestApplyJSONPatchMove(t *testing.T) {
+	doc := newPatchDoc()
+	err := applyPatch(t, &doc, `[
+		{"op": "move", "from": "/items/0", "path": "/items/-"},
+		{"op": "move", "from": "/name", "path": "/nick"},
+		{"op": "move", "from": "/tags/0", "path": "/tags/0"},
+		{"op": "remove", "path": "/address"}
+	]`)
+	if err != nil {
+		t.Fatalf("ApplyJSONPatch failed with error: %v", err)
+	}
+	if !reflect.DeepEqual(doc.Items, []patchItem{{"y", 2}, {"x", 1}}) {
+		t.Errorf("Unexpected items %v", doc.Items)
+	}
+	if doc.Name != "" || doc.Nick == nil || *doc.Nick != "Alice" {
+		t.Errorf("Expected name to move to nick, got %q and %v", doc.Name, doc.Nick)
+	}
+	if !reflect.DeepEqual(doc.Tags, []string{"a", "b"}) {
+		t.Errorf("Unexpected tags %v", doc.Tags)
+	}
+	if doc.Address != nil {
+		t.Errorf("Expected address to be removed, got %+v", doc.Address)
+	}
+}
+
+func TestApplyJSONPatchCopyIsIndependent(t *testing.T) {
+	doc := newPatchDoc()
+	err := applyPatch(t, &doc, `[
+		{"op": "copy", "from": "/meta", "path": "/meta/copy"},
+		{"op": "replace", "path": "/meta/copy/prefs/theme", "value": "light"}
+	]`)
+	if err != nil {
+		t.Fatalf("ApplyJSONPatch failed with error: %v", err)
+	}
+	prefs := doc.Meta["prefs"].(map[string]interface{})
+	if prefs["theme"] != "dark" {
+		t.Errorf("Expected original to be unchanged, got %v", prefs["theme"])
+	}
+}
+
+func TestApplyJSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr error
+		index   int
+		path    string
+	}{
+		{"TestFailed", `[{"op": "test", "path": "/version", "value": 2}]`, ErrPatchTestFailed, 0, "/version"},
+		{"TestNullZeroValue", `[{"op": "test", "path": "/a~1b~0c", "value": null}]`, ErrPatchTestFailed, 0, "/a~1b~0c"},
+		{"TestNullNonNil", `[{"op": "test", "path": "/address", "value": null}]`, ErrPatchTestFailed, 0, "/address"},
+		{"TestStringNumber", `[{"op": "test", "path": "/version", "value": "3"}]`, ErrPatchTestFailed, 0, "/version"},
+		{"TestNumberBool", `[{"op": "test", "path": "/flag", "value": 1}]`, ErrPatchTestFailed, 0, "/flag"},
+		{"TestNumberString", `[{"op": "test", "path": "/name", "value": 0}]`, ErrPatchTestFailed, 0, "/name"},
+		{"TestObjectMissingMember", `[{"op": "test", "path": "/items/0", "value": {"sku": "x"}}]`, ErrPatchTestFailed, 0, "/items/0"},
+		{"UnknownField", `[{"op": "replace", "path": "/missing", "value": 1}]`, ErrPathNotFound, 0, "/missing"},
+		{"IndexOutOfRange", `[{"op": "replace", "path": "/tags/5", "value": "x"}]`, ErrPathNotFound, 0, "/tags/5"},
+		{"ReplaceMissingKey", `[{"op": "replace", "path": "/labels/nope", "value": 1}]`, ErrPathNotFound, 0, "/labels/nope"},
+		{"RemoveMissingKey", `[{"op": "remove", "path": "/labels/nope"}]`, ErrPathNotFound, 0, "/labels/nope"},
+		{"NilPointer", `[{"op": "add", "path": "/nick/x", "value": 1}]`, ErrPathNotFound, 0, "/nick/x"},
+		{"LeadingZero", `[{"op": "replace", "path": "/tags/01", "value": "x"}]`, ErrPathNotFound, 0, "/tags/01"},
+		{"UnknownOp", `[{"op": "merge", "path": "/name"}]`, ErrInvalidPatch, 0, "/name"},
+		{"MissingValue", `[{"op": "add", "path": "/name"}]`, ErrInvalidPatch, 0, "/name"},
+		{"BadPointer", `[{"op": "remove", "path": "name"}]`, ErrInvalidPatch, 0, "name"},
+		{"MoveIntoChild", `[{"op": "move", "from": "/meta", "path": "/meta/inner"}]`, ErrInvalidPatch, 0, "/meta/inner"},
+		{"LaterOperation", `[{"op": "replace", "path": "/name", "value": "Bob"}, {"op": "test", "path": "/name", "value": "Alice"}]`, ErrPatchTestFailed, 1, "/name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := newPatchDoc()
+			err := applyPatch(t, &doc, tt.patch)
+
+			var patchErr *PatchError
+			if !errors.As(err, &patchErr) {
+				t.Fatalf("Expected a *PatchError, got %v", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if patchErr.Index != tt.index || patchErr.Path != tt.path {
+				t.Errorf("Expected operation %d at %s, got %d at %s", tt.index, tt.path, patchErr.Index, patchErr.Path)
+			}
+			if !reflect.DeepEqual(doc, newPatchDoc()) {
+				t.Errorf("Expected a failed patch to leave the target unchanged, got %+v", doc)
+			}
+		})
+	}
+}
+
+func TestApplyJSONPatchConversionError(t *testing.T) {
+	doc := newPatchDoc()
+	err := applyPatch(t, &doc, `[{"op": "replace", "path": "/version", "value": "four"}]`)
+
+	var patchErr *PatchError
+	if !errors.As(err, &patchErr) || patchErr.Op != "replace" {
+		t.Fatalf("Expected a *PatchError for replace, got %v", err)
+	}
+}
+
+func TestApplyJSONPatchNotArray(t *testing.T) {
+	doc := newPatchDoc()
+	if err := applyPatch(t, &doc, `{"op": "remove"}`); !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("Expected ErrInvalidPatch, got %v", err)
+	}
+}
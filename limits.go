@@ -0,0 +1,128 @@
+package binder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Errors returned when a decoded body exceeds the structural limits in
+// BindOptions. Returned errors wrap one of these and state the limit.
+var (
+	ErrBodyTooDeep  = errors.New("request body nested too deeply")
+	ErrArrayTooLong = errors.New("request body array too long")
+	ErrTooManyKeys  = errors.New("request body object has too many keys")
+)
+
+// hasStructuralLimits reports whether any structural limit is configured
+func (o *BindOptions) hasStructuralLimits() bool {
+	return o.MaxDepth > 0 || o.MaxArrayLength > 0 || o.MaxKeys > 0
+}
+
+// checkDepth fails if depth exceeds the configured maximum
+func (o *BindOptions) checkDepth(depth int) error {
+	if o.MaxDepth > 0 && depth > o.MaxDepth {
+		return fmt.Errorf("%w: maximum depth is %d", ErrBodyTooDeep, o.MaxDepth)
+	}
+	return nil
+}
+
+// checkArrayLength fails if n exceeds the configured maximum array length
+func (o *BindOptions) checkArrayLength(n int) error {
+	if o.MaxArrayLength > 0 && n > o.MaxArrayLength {
+		return fmt.Errorf("%w: maximum length is %d", ErrArrayTooLong, o.MaxArrayLength)
+	}
+	return nil
+}
+
+// checkKeys fails if n exceeds the configured maximum number of object keys
+func (o *BindOptions) checkKeys(n int) error {
+	if o.MaxKeys > 0 && n > o.MaxKeys {
+		return fmt.Errorf("%w: maximum is %d", ErrTooManyKeys, o.MaxKeys)
+	}
+	return nil
+}
+
+// checkLimits walks decoded body data and enforces the structural limits.
+// The top-level object is at depth 1.
+func checkLimits(value interface{}, opts *BindOptions, depth int) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if err := opts.checkDepth(depth); err != nil {
+			return err
+		}
+		if err := opts.checkKeys(len(v)); err != nil {
+			return err
+		}
+		for _, elem := range v {
+			if err := checkLimits(elem, opts, depth+1); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if err := opts.checkDepth(depth); err != nil {
+			return err
+		}
+		if err := opts.checkArrayLength(len(v)); err != nil {
+			return err
+		}
+		for _, elem := range v {
+			if err := checkLimits(elem, opts, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeJSONValue decodes the next JSON value at the given depth
+func decodeJSONValue(dec *json.Decoder, opts *BindOptions, depth int) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+	if err := opts.checkDepth(depth); err != nil {
+		return nil, err
+	}
+
+	switch delim {
+	case '{':
+		m := make(map[string]interface{})
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string)
+			if err := opts.checkKeys(len(m) + 1); err != nil {
+				return nil, err
+			}
+			if m[key], err = decodeJSONValue(dec, opts, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		_, err = dec.Token() // closing brace
+		return m, err
+
+	case '[':
+		list := make([]interface{}, 0)
+		for dec.More() {
+			if err := opts.checkArrayLength(len(list) + 1); err != nil {
+				return nil, err
+			}
+			elem, err := decodeJSONValue(dec, opts, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, elem)
+		}
+		_, err = dec.Token() // closing bracket
+		return list, err
+	}
+	return nil, fmt.Errorf("unexpected delimiter %q", delim)
+}
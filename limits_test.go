@@ -0,0 +1,99 @@
+package binder
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBindStructuralLimits(t *testing.T) {
+	type params struct {
+		Name string `body:"name"`
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		opts    BindOptions
+		want    error
+	}{
+		{"Depth", `{"a": {"b": {"c": {}}}}`, BindOptions{MaxDepth: 3}, ErrBodyTooDeep},
+		{"DepthInArray", `{"a": [[[1]]]}`, BindOptions{MaxDepth: 3}, ErrBodyTooDeep},
+		{"ArrayLength", `{"a": [1, 2, 3, 4]}`, BindOptions{MaxArrayLength: 3}, ErrArrayTooLong},
+		{"Keys", `{"a": 1, "b": 2, "c": 3}`, BindOptions{MaxKeys: 2}, ErrTooManyKeys},
+		{"NestedKeys", `{"a": {"x": 1, "y": 2, "z": 3}}`, BindOptions{MaxKeys: 2}, ErrTooManyKeys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/test", strings.NewReader(tt.payload))
+			r.Header.Set("Content-Type", "application/json")
+
+			var p params
+			err := BindWithOptions(r, &p, tt.opts)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got: %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBindWithinStructuralLimits(t *testing.T) {
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name": "Alice", "tags": ["a", "b", "c"], "address": {"city": "Paris"}}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	type address struct {
+		City string `body:"city"`
+	}
+
+	type params struct {
+		Name    string   `body:"name"`
+		Tags    []string `body:"tags"`
+		Address address  `body:"address"`
+	}
+
+	var p params
+	opts := BindOptions{MaxDepth: 2, MaxArrayLength: 3, MaxKeys: 3}
+	if err := BindWithOptions(r, &p, opts); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.Name != "Alice" || len(p.Tags) != 3 || p.Address.City != "Paris" {
+		t.Errorf("Unexpected result %+v", p)
+	}
+}
+
+func TestBindStructuralLimitsForm(t *testing.T) {
+	form := url.Values{}
+	for i := 0; i < 5; i++ {
+		form.Add("id", "1")
+	}
+
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var p struct {
+		IDs []int `body:"id"`
+	}
+	err := BindWithOptions(r, &p, BindOptions{MaxArrayLength: 4})
+	if !errors.Is(err, ErrArrayTooLong) {
+		t.Errorf("Expected ErrArrayTooLong, got: %v", err)
+	}
+}
+
+func TestSetSliceArrayLimit(t *testing.T) {
+	var p struct {
+		IDs []int
+	}
+	field := reflect.ValueOf(&p).Elem().Field(0)
+
+	err := setSlice(field, []interface{}{1.0, 2.0, 3.0}, "", &BindOptions{MaxArrayLength: 2})
+	if !errors.Is(err, ErrArrayTooLong) {
+		t.Errorf("Expected ErrArrayTooLong, got: %v", err)
+	}
+	if p.IDs != nil {
+		t.Errorf("Expected no slice to be allocated, got %v", p.IDs)
+	}
+}
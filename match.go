@@ -0,0 +1,139 @@
+package binder
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// KeyMatching is how body, form and query keys are matched to the names in
+// struct tags
+type KeyMatching int
+
+const (
+	MatchExact           KeyMatching = iota // keys must equal tag names
+	MatchCaseInsensitive                    // keys may differ in case, as encoding/json allows
+	MatchNormalized                         // keys may also differ in _ and - separators, so page_size, pageSize and page-size match
+)
+
+// equal reports whether a request key matches a tag name
+func (m KeyMatching) equal(key, name string) bool {
+	switch m {
+	case MatchCaseInsensitive:
+		return strings.EqualFold(key, name)
+	case MatchNormalized:
+		return normalizeKey(key) == normalizeKey(name)
+	}
+	return key == name
+}
+
+// normalizeKey lower-cases a key and drops its word separators, giving
+// snake_case, camelCase and kebab-case spellings of a name the same form
+func normalizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(key))
+}
+
+// fold returns the form of a key that matching compares, so that keys
+// equal reports as matching fold to the same string
+func (m KeyMatching) fold(key string) string {
+	switch m {
+	case MatchCaseInsensitive:
+		return foldCase(key)
+	case MatchNormalized:
+		return normalizeKey(key)
+	}
+	return key
+}
+
+// foldCase maps each rune of s to the smallest rune in its case folding
+// orbit, the equivalence strings.EqualFold uses
+func foldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		folded := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < folded {
+				folded = f
+			}
+		}
+		return folded
+	}, s)
+}
+
+// keyIndex maps the folded form of each key of a decoded object to the key
+// lookupKey matches, the first in sorted order
+type keyIndex map[string]string
+
+// newKeyIndex folds the keys of data
+func newKeyIndex(data map[string]interface{}, match KeyMatching) keyIndex {
+	index := make(keyIndex, len(data))
+	for k := range data {
+		folded := match.fold(k)
+		if first, ok := index[folded]; !ok || k < first {
+			index[folded] = k
+		}
+	}
+	return index
+}
+
+// keyIndexCache holds the index of each object matched during one call, so
+// an object's keys are folded once rather than once per field. Entries keep
+// their object so its address cannot be reused while it is cached.
+type keyIndexCache map[uintptr]indexedObject
+
+type indexedObject struct {
+	data  map[string]interface{}
+	index keyIndex
+}
+
+// keyIndex returns the index of data's keys, building it on first use
+func (o *BindOptions) keyIndex(data map[string]interface{}) keyIndex {
+	if o.keyIndexes == nil {
+		o.keyIndexes = make(keyIndexCache)
+	}
+	ptr := reflect.ValueOf(data).Pointer()
+	if cached, ok := o.keyIndexes[ptr]; ok {
+		return cached.index
+	}
+	index := newKeyIndex(data, o.KeyMatching)
+	o.keyIndexes[ptr] = indexedObject{data, index}
+	return index
+}
+
+// lookupKey finds the value for a tag name in decoded data. An exact match
+// wins; otherwise the first matching key in sorted order is used.
+func lookupKey(data map[string]interface{}, name string, opts *BindOptions) (interface{}, bool) {
+	if v, ok := data[name]; ok || opts.KeyMatching == MatchExact {
+		return v, ok
+	}
+	key, found := opts.keyIndex(data)[opts.KeyMatching.fold(name)]
+	if !found {
+		return nil, false
+	}
+	v, ok := data[key]
+	return v, ok
+}
+
+// queryKey returns the spelling of a query parameter name used in values,
+// including as the root of nested keys such as Filter[status]. It returns
+// name itself when the query string has no matching key.
+func queryKey(values url.Values, name string, match KeyMatching) string {
+	if match == MatchExact || values.Has(name) || hasNestedKey(values, name) {
+		return name
+	}
+	key, found := name, false
+	for k := range values {
+		if segments, ok := splitKey(k); ok {
+			k = segments[0]
+		}
+		if match.equal(k, name) && (!found || k < key) {
+			key, found = k, true
+		}
+	}
+	return key
+}
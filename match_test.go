@@ -0,0 +1,165 @@
+package binder
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestKeyMatchingEqual(t *testing.T) {
+	tests := []struct {
+		match KeyMatching
+		key   string
+		name  string
+		want  bool
+	}{
+		{MatchExact, "email", "email", true},
+		{MatchExact, "Email", "email", false},
+		{MatchCaseInsensitive, "Email", "email", true},
+		{MatchCaseInsensitive, "page_size", "pageSize", false},
+		{MatchNormalized, "page_size", "pageSize", true},
+		{MatchNormalized, "page-size", "PageSize", true},
+		{MatchNormalized, "page_sizes", "pageSize", false},
+		{MatchCaseInsensitive, "\u212Aelvin", "kelvin", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.match.equal(tt.key, tt.name); got != tt.want {
+			t.Errorf("Expected equal(%q, %q) with %d to be %v, got %v", tt.key, tt.name, tt.match, tt.want, got)
+		}
+		if folded := tt.match.fold(tt.key) == tt.match.fold(tt.name); folded != tt.want {
+			t.Errorf("Expected folded %q and %q with %d to match: %v, got %v", tt.key, tt.name, tt.match, tt.want, folded)
+		}
+	}
+}
+
+func TestLookupKeyPrefersExactMatch(t *testing.T) {
+	data := map[string]interface{}{"Email": "upper", "email": "exact", "EMAIL": "shout"}
+
+	if v, _ := lookupKey(data, "email", &BindOptions{KeyMatching: MatchCaseInsensitive}); v != "exact" {
+		t.Errorf("Expected exact match, got %v", v)
+	}
+	delete(data, "email")
+	if v, _ := lookupKey(data, "email", &BindOptions{KeyMatching: MatchCaseInsensitive}); v != "shout" {
+		t.Errorf("Expected first key in sorted order, got %v", v)
+	}
+}
+
+func TestLookupKeyIndexesOnce(t *testing.T) {
+	data := map[string]interface{}{"Page_Size": 10, "page-size": 20, "Sort": "asc"}
+	opts := &BindOptions{KeyMatching: MatchNormalized}
+
+	if v, _ := lookupKey(data, "pageSize", opts); v != 10 {
+		t.Errorf("Expected first key in sorted order, got %v", v)
+	}
+	if v, _ := lookupKey(data, "sort", opts); v != "asc" {
+		t.Errorf("Expected sort to be asc, got %v", v)
+	}
+	if len(opts.keyIndexes) != 1 {
+		t.Errorf("Expected one cached index, got %d", len(opts.keyIndexes))
+	}
+}
+
+func TestQueryKey(t *testing.T) {
+	values := url.Values{"Page_Size": {"10"}, "Filter[status]": {"open"}}
+
+	if got := queryKey(values, "pageSize", MatchNormalized); got != "Page_Size" {
+		t.Errorf("Expected Page_Size, got %q", got)
+	}
+	if got := queryKey(values, "filter", MatchCaseInsensitive); got != "Filter" {
+		t.Errorf("Expected Filter, got %q", got)
+	}
+	if got := queryKey(values, "pageSize", MatchExact); got != "pageSize" {
+		t.Errorf("Expected pageSize, got %q", got)
+	}
+}
+
+type matchFilter struct {
+	Status string `query:"status"`
+}
+
+type matchRequest struct {
+	PageSize int         `query:"page_size"`
+	Filter   matchFilter `query:"filter"`
+	Email    string      `body:"email"`
+	Address  struct {
+		ZipCode string `json:"zip_code"`
+	} `json:"address"`
+}
+
+func TestBindKeyMatching(t *testing.T) {
+	tests := []struct {
+		name        string
+		match       KeyMatching
+		url         string
+		contentType string
+		body        string
+		wantEmail   string
+		wantZip     string
+		wantSize    int
+		wantStatus  string
+	}{
+		{
+			name:        "exact ignores other spellings",
+			match:       MatchExact,
+			url:         "/?PageSize=5",
+			contentType: "application/json",
+			body:        `{"Email":"a@example.com"}`,
+		},
+		{
+			name:        "case insensitive",
+			match:       MatchCaseInsensitive,
+			url:         "/?PAGE_SIZE=5&FILTER[Status]=open",
+			contentType: "application/json",
+			body:        `{"Email":"a@example.com","Address":{"ZIP_CODE":"12345"}}`,
+			wantEmail:   "a@example.com",
+			wantZip:     "12345",
+			wantSize:    5,
+			wantStatus:  "open",
+		},
+		{
+			name:        "normalized",
+			match:       MatchNormalized,
+			url:         "/?pageSize=5",
+			contentType: "application/json",
+			body:        `{"address":{"zipCode":"12345"}}`,
+			wantZip:     "12345",
+			wantSize:    5,
+		},
+		{
+			name:        "normalized form",
+			match:       MatchNormalized,
+			url:         "/",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "EMAIL=a@example.com&address[zip-code]=12345",
+			wantEmail:   "a@example.com",
+			wantZip:     "12345",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			var r matchRequest
+			opts := BindOptions{KeyMatching: tt.match, RejectUnknownFields: tt.match != MatchExact}
+			if err := BindWithOptions(req, &r, opts); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if r.Email != tt.wantEmail {
+				t.Errorf("Expected Email to be %q, got %q", tt.wantEmail, r.Email)
+			}
+			if r.Address.ZipCode != tt.wantZip {
+				t.Errorf("Expected ZipCode to be %q, got %q", tt.wantZip, r.Address.ZipCode)
+			}
+			if r.PageSize != tt.wantSize {
+				t.Errorf("Expected PageSize to be %d, got %d", tt.wantSize, r.PageSize)
+			}
+			if r.Filter.Status != tt.wantStatus {
+				t.Errorf("Expected Status to be %q, got %q", tt.wantStatus, r.Filter.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package binder
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NamingStrategy derives the request key of a field whose bind tag names a
+// source without a key, such as `bind:"query"`
+type NamingStrategy int
+
+const (
+	NameExact     NamingStrategy = iota // the Go field name, e.g. PageSize
+	NameSnakeCase                       // e.g. page_size
+	NameCamelCase                       // e.g. pageSize
+)
+
+// name returns the request key for a Go field name
+func (n NamingStrategy) name(fieldName string) string {
+	switch n {
+	case NameSnakeCase:
+		words := splitWords(fieldName)
+		for i, w := range words {
+			words[i] = strings.ToLower(w)
+		}
+		return strings.Join(words, "_")
+	case NameCamelCase:
+		words := splitWords(fieldName)
+		for i, w := range words {
+			if i == 0 {
+				words[i] = strings.ToLower(w)
+			} else {
+				first, size := utf8.DecodeRuneInString(w)
+				words[i] = string(unicode.ToUpper(first)) + strings.ToLower(w[size:])
+			}
+		}
+		return strings.Join(words, "")
+	}
+	return fieldName
+}
+
+// splitWords splits a Go identifier into words, keeping initialisms
+// together, so APIKey becomes API and Key and UserID becomes User and ID
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		switch {
+		case cur == '_':
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+		case unicode.IsLower(prev) && unicode.IsUpper(cur),
+			unicode.IsDigit(prev) && unicode.IsUpper(cur):
+			words = append(words, string(runes[start:i]))
+			start = i
+		case unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// The last capital of an initialism starts the next word
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// structFieldKey identifies the fields of a struct type as seen with a
+// given set of tag names
+type structFieldKey struct {
+	typ  reflect.Type
+	tags TagNames
+}
+
+// Cache of struct fields as binding sees them
+var structFieldCache = make(map[structFieldKey][]reflect.StructField)
+var structFieldMutex sync.RWMutex
+
+// structFields returns the fields of a struct type with the tags binding
+// reads, renamed from any custom tag names to the defaults. A blank field
+// such as
+//
+//	_ struct{} `bind:"query"`
+//
+// sets the default source of the struct: each exported field with no
+// binding tag of its own is given that bind tag, and the blank field's
+// tag is dropped.
+func structFields(typ reflect.Type, tags TagNames) []reflect.StructField {
+	key := structFieldKey{typ, tags}
+	structFieldMutex.RLock()
+	fields, found := structFieldCache[key]
+	structFieldMutex.RUnlock()
+
+	if found {
+		return fields
+	}
+
+	fields = make([]reflect.StructField, typ.NumField())
+	defaultTag := ""
+	for i := range fields {
+		fields[i] = typ.Field(i)
+		if tags != (TagNames{}) {
+			fields[i].Tag = tags.rewrite(fields[i].Tag)
+		}
+		if fields[i].Name == "_" {
+			if tag := fields[i].Tag.Get(bind); tag != "" {
+				defaultTag = tag
+				fields[i].Tag = ""
+			}
+		}
+	}
+	if defaultTag != "" {
+		for i, field := range fields {
+			if field.IsExported() && !field.Anonymous && field.Type != presenceType && !hasBindingTag(field) {
+				fields[i].Tag = reflect.StructTag(strings.TrimSpace(string(field.Tag) + " bind:" + strconv.Quote(defaultTag)))
+			}
+		}
+	}
+
+	structFieldMutex.Lock()
+	structFieldCache[key] = fields
+	structFieldMutex.Unlock()
+	return fields
+}
+
+// hasBindingTag reports whether a field has any tag binding reads
+func hasBindingTag(field reflect.StructField) bool {
+	for _, source := range []string{bind, path, query, body, jjson, xxml, cookie} {
+		if _, ok := field.Tag.Lookup(source); ok {
+			return true
+		}
+	}
+	return false
+}
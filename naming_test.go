@@ -0,0 +1,136 @@
+package binder
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNamingStrategy(t *testing.T) {
+	tests := []struct {
+		field string
+		snake string
+		camel string
+	}{
+		{"PageSize", "page_size", "pageSize"},
+		{"ID", "id", "id"},
+		{"UserID", "user_id", "userId"},
+		{"APIKey", "api_key", "apiKey"},
+		{"HTTPServer", "http_server", "httpServer"},
+		{"Sort_Order", "sort_order", "sortOrder"},
+		{"Page2Size", "page2_size", "page2Size"},
+		{"name", "name", "name"},
+		{"NameÄpfel", "name_äpfel", "nameÄpfel"},
+		{"ÜberName", "über_name", "überName"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			if got := NameSnakeCase.name(tt.field); got != tt.snake {
+				t.Errorf("Expected snake case to be %q, got %q", tt.snake, got)
+			}
+			if got := NameCamelCase.name(tt.field); got != tt.camel {
+				t.Errorf("Expected camel case to be %q, got %q", tt.camel, got)
+			}
+			if got := NameExact.name(tt.field); got != tt.field {
+				t.Errorf("Expected exact name to be %q, got %q", tt.field, got)
+			}
+		})
+	}
+}
+
+func TestBindDerivedKeys(t *testing.T) {
+	type request struct {
+		PageSize int    `bind:"query"`
+		APIKey   string `bind:"header=X-Api-Key,query"`
+	}
+
+	tests := []struct {
+		name     string
+		naming   NamingStrategy
+		url      string
+		wantSize int
+		wantKey  string
+	}{
+		{"exact", NameExact, "/?PageSize=10&APIKey=k", 10, "k"},
+		{"snake case", NameSnakeCase, "/?page_size=10&api_key=k", 10, "k"},
+		{"camel case", NameCamelCase, "/?pageSize=10&apiKey=k", 10, "k"},
+		{"other spelling", NameSnakeCase, "/?pageSize=10", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+
+			var r request
+			if err := BindWithOptions(req, &r, BindOptions{Naming: tt.naming}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if r.PageSize != tt.wantSize {
+				t.Errorf("Expected PageSize to be %d, got %d", tt.wantSize, r.PageSize)
+			}
+			if r.APIKey != tt.wantKey {
+				t.Errorf("Expected APIKey to be %q, got %q", tt.wantKey, r.APIKey)
+			}
+		})
+	}
+}
+
+type defaultSourceFilter struct {
+	_         struct{} `bind:"query"`
+	MinPrice  int
+	SortOrder string
+}
+
+type defaultSourceRequest struct {
+	_        struct{} `bind:"query"`
+	PageSize int
+	Filter   defaultSourceFilter
+	ID       int    `path:"id"`
+	Name     string `body:"name"`
+	Present  Presence
+	internal string
+}
+
+func TestStructFieldsDefaultSource(t *testing.T) {
+	fields := structFields(reflect.TypeOf(defaultSourceRequest{}), TagNames{})
+
+	want := map[string]string{
+		"_":        "",
+		"PageSize": "query",
+		"Filter":   "query",
+		"ID":       "",
+		"Name":     "",
+		"Present":  "",
+		"internal": "",
+	}
+	for _, f := range fields {
+		if got := f.Tag.Get(bind); got != want[f.Name] {
+			t.Errorf("Expected bind tag of %s to be %q, got %q", f.Name, want[f.Name], got)
+		}
+	}
+}
+
+func TestBindDefaultSource(t *testing.T) {
+	req := httptest.NewRequest("POST", "/?page_size=20&filter[min_price]=5&filter[sort_order]=desc", strings.NewReader(`{"name":"widgets"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var r defaultSourceRequest
+	opts := BindOptions{Naming: NameSnakeCase, RejectUnknownFields: true}
+	if err := BindWithOptions(req, &r, opts); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if r.PageSize != 20 {
+		t.Errorf("Expected PageSize to be 20, got %d", r.PageSize)
+	}
+	if r.Filter.MinPrice != 5 || r.Filter.SortOrder != "desc" {
+		t.Errorf("Expected Filter to be {5 desc}, got %+v", r.Filter)
+	}
+	if r.Name != "widgets" {
+		t.Errorf("Expected Name to be widgets, got %q", r.Name)
+	}
+	if !r.Present.Has("page_size") || r.Present.Source("PageSize") != "query" {
+		t.Errorf("Expected page_size to be present from query, got %v", r.Present.Keys())
+	}
+}
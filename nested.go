@@ -0,0 +1,234 @@
+package binder
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formList collects the elements of a list built from form keys. Indexed
+// elements such as items[3] are kept apart from appended ones such as
+// items[] until the keys have all been read.
+type formList struct {
+	indexed  map[int]interface{}
+	appended []interface{}
+}
+
+// expandKeys adds nested maps and lists to flat form or query data for keys
+// written in bracket or dot notation, so that address[street]=x,
+// address.street=x and items[0][sku]=y reach nested structs and slices the
+// way JSON objects do. The flat keys are kept, so tags naming them still
+// match. Keys that conflict with a plain value of the same name are ignored.
+func expandKeys(flat map[string]interface{}) map[string]interface{} {
+	// Sorted keys make the first of two conflicting keys win consistently
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var nested map[string]interface{}
+	for _, key := range keys {
+		segments, ok := splitKey(key)
+		if !ok {
+			continue
+		}
+		if nested == nil {
+			nested = make(map[string]interface{})
+		}
+		insertNested(nested, segments, flat[key])
+	}
+
+	for root, value := range nested {
+		if _, exists := flat[root]; !exists {
+			flat[root] = finishNested(value)
+		}
+	}
+	return flat
+}
+
+// valuesMap converts url.Values to the map shape produced by the form
+// decoder: a string for a single value and a list for repeated keys
+func valuesMap(values url.Values) map[string]interface{} {
+	m := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		if len(v) == 1 {
+			m[k] = v[0]
+			continue
+		}
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = v[i]
+		}
+		m[k] = list
+	}
+	return m
+}
+
+// hasNestedKey reports whether any key starts with name in bracket or dot
+// notation, as filter[status] and filter.status do for filter
+func hasNestedKey(values url.Values, name string) bool {
+	for key := range values {
+		if len(key) > len(name) && strings.HasPrefix(key, name) && (key[len(name)] == '[' || key[len(name)] == '.') {
+			return true
+		}
+	}
+	return false
+}
+
+// splitKey splits a key such as items[0][sku] or address.street into its
+// segments. It reports false for plain keys and malformed ones.
+func splitKey(key string) ([]string, bool) {
+	i := strings.IndexAny(key, "[.")
+	if i <= 0 {
+		return nil, false
+	}
+
+	segments := []string{key[:i]}
+	rest := key[i:]
+	for rest != "" {
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, false
+			}
+			segments = append(segments, rest[1:end])
+			rest = rest[end+1:]
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, "[.")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, false
+			}
+			segments = append(segments, rest[:end])
+			rest = rest[end:]
+		default:
+			return nil, false
+		}
+	}
+	return segments, true
+}
+
+// insertNested stores value in m under the path given by segments,
+// creating maps for named segments and lists for index segments
+func insertNested(m map[string]interface{}, segments []string, value interface{}) {
+	key := segments[0]
+	if len(segments) == 1 {
+		if _, exists := m[key]; !exists {
+			m[key] = value
+		}
+		return
+	}
+
+	child, exists := m[key]
+	if !exists {
+		child = newNestedContainer(segments[1])
+		m[key] = child
+	}
+	insertChild(child, segments[1:], value)
+}
+
+// insertChild stores value in a map or list created by insertNested
+func insertChild(container interface{}, segments []string, value interface{}) {
+	switch c := container.(type) {
+	case map[string]interface{}:
+		insertNested(c, segments, value)
+
+	case *formList:
+		index, isIndex := listIndex(segments[0])
+		if !isIndex {
+			return
+		}
+
+		if len(segments) == 1 {
+			if index < 0 {
+				// items[]=a&items[]=b appends every value
+				if list, ok := value.([]interface{}); ok {
+					c.appended = append(c.appended, list...)
+				} else {
+					c.appended = append(c.appended, value)
+				}
+			} else if _, exists := c.indexed[index]; !exists {
+				c.indexed[index] = value
+			}
+			return
+		}
+
+		if index < 0 {
+			// items[][sku]=a&items[][sku]=b starts a new element per value
+			values, ok := value.([]interface{})
+			if !ok {
+				values = []interface{}{value}
+			}
+			for _, v := range values {
+				child := newNestedContainer(segments[1])
+				c.appended = append(c.appended, child)
+				insertChild(child, segments[1:], v)
+			}
+			return
+		}
+		child, exists := c.indexed[index]
+		if !exists {
+			child = newNestedContainer(segments[1])
+			c.indexed[index] = child
+		}
+		insertChild(child, segments[1:], value)
+	}
+}
+
+// newNestedContainer returns the container a segment indexes into: a list
+// for an index such as 0 or an empty [], otherwise a map
+func newNestedContainer(segment string) interface{} {
+	if _, isIndex := listIndex(segment); isIndex {
+		return &formList{indexed: make(map[int]interface{})}
+	}
+	return make(map[string]interface{})
+}
+
+// listIndex parses a list index segment. An empty segment is an append
+// and reports -1.
+func listIndex(segment string) (int, bool) {
+	if segment == "" {
+		return -1, true
+	}
+	i, err := strconv.Atoi(segment)
+	if err != nil || i < 0 {
+		return 0, false
+	}
+	return i, true
+}
+
+// finishNested converts the lists built by insertNested into slices.
+// Indexed elements are ordered by index without gaps, so a sparse index
+// such as items[1000000] cannot inflate the result.
+func finishNested(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, elem := range v {
+			v[k] = finishNested(elem)
+		}
+		return v
+
+	case *formList:
+		indexes := make([]int, 0, len(v.indexed))
+		for i := range v.indexed {
+			indexes = append(indexes, i)
+		}
+		sort.Ints(indexes)
+
+		list := make([]interface{}, 0, len(indexes)+len(v.appended))
+		for _, i := range indexes {
+			list = append(list, finishNested(v.indexed[i]))
+		}
+		for _, elem := range v.appended {
+			list = append(list, finishNested(elem))
+		}
+		return list
+	}
+	return value
+}
@@ -0,0 +1,175 @@
+package binder
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		segments []string
+		ok       bool
+	}{
+		{"name", nil, false},
+		{"address[street]", []string{"address", "street"}, true},
+		{"address.street", []string{"address", "street"}, true},
+		{"items[0][sku]", []string{"items", "0", "sku"}, true},
+		{"items[0].sku", []string{"items", "0", "sku"}, true},
+		{"tags[]", []string{"tags", ""}, true},
+		{"a.b.c", []string{"a", "b", "c"}, true},
+		{"[0]", nil, false},
+		{".a", nil, false},
+		{"a[b", nil, false},
+		{"a..b", nil, false},
+		{"a[b]c", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			segments, ok := splitKey(tt.key)
+			if ok != tt.ok || !reflect.DeepEqual(segments, tt.segments) {
+				t.Errorf("Expected %v, %v, got %v, %v", tt.segments, tt.ok, segments, ok)
+			}
+		})
+	}
+}
+
+func TestExpandKeys(t *testing.T) {
+	flat := map[string]interface{}{
+		"name":            "Alice",
+		"address[street]": "Main St",
+		"address.city":    "Springfield",
+		"items[1][sku]":   "b",
+		"items[0][sku]":   "a",
+		"items[0][qty]":   "2",
+		"tags[]":          []interface{}{"x", "y"},
+		"ids[1000000]":    "7",
+		"name[first]":     "ignored",
+	}
+
+	got := expandKeys(flat)
+	want := map[string]interface{}{
+		"address": map[string]interface{}{"street": "Main St", "city": "Springfield"},
+		"items": []interface{}{
+			map[string]interface{}{"sku": "a", "qty": "2"},
+			map[string]interface{}{"sku": "b"},
+		},
+		"tags": []interface{}{"x", "y"},
+		"ids":  []interface{}{"7"},
+		"name": "Alice",
+	}
+	for key, value := range want {
+		if !reflect.DeepEqual(got[key], value) {
+			t.Errorf("Expected %s to be %#v, got %#v", key, value, got[key])
+		}
+	}
+	if got["address[street]"] != "Main St" {
+		t.Errorf("Expected flat keys to be kept, got %#v", got["address[street]"])
+	}
+}
+
+type nestedAddress struct {
+	Street string `body:"street"`
+	City   string `json:"city"`
+}
+
+type nestedItem struct {
+	SKU string `body:"sku"`
+	Qty int    `body:"qty"`
+}
+
+type nestedOrder struct {
+	Name    string         `body:"name"`
+	Address nestedAddress  `body:"address"`
+	Billing *nestedAddress `body:"billing"`
+	Items   []nestedItem   `body:"items"`
+	Tags    []string       `body:"tags"`
+}
+
+func TestBindNestedForm(t *testing.T) {
+	form := "name=Alice&address[street]=Main+St&address[city]=Springfield" +
+		"&billing.street=Elm+St&items[0][sku]=a&items[0][qty]=2&items[1][sku]=b&items[1][qty]=1" +
+		"&tags[]=new&tags[]=gift"
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(form))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var order nestedOrder
+	if err := Bind(r, &order); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+
+	if order.Name != "Alice" {
+		t.Errorf("Expected Name to be 'Alice', got '%s'", order.Name)
+	}
+	if order.Address != (nestedAddress{"Main St", "Springfield"}) {
+		t.Errorf("Unexpected address %+v", order.Address)
+	}
+	if order.Billing == nil || order.Billing.Street != "Elm St" {
+		t.Errorf("Unexpected billing address %+v", order.Billing)
+	}
+	if len(order.Items) != 2 || order.Items[0] != (nestedItem{"a", 2}) || order.Items[1] != (nestedItem{"b", 1}) {
+		t.Errorf("Unexpected items %+v", order.Items)
+	}
+	if !reflect.DeepEqual(order.Tags, []string{"new", "gift"}) {
+		t.Errorf("Unexpected tags %v", order.Tags)
+	}
+}
+
+func TestBindNestedFormMatchesJSON(t *testing.T) {
+	jsonReq := httptest.NewRequest("POST", "/orders", strings.NewReader(
+		`{"name": "Alice", "address": {"street": "Main St", "city": "Springfield"}, "items": [{"sku": "a", "qty": 2}]}`))
+	jsonReq.Header.Set("Content-Type", "application/json")
+	formReq := httptest.NewRequest("POST", "/orders", strings.NewReader(
+		"name=Alice&address.street=Main+St&address.city=Springfield&items[0].sku=a&items[0].qty=2"))
+	formReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var fromJSON, fromForm nestedOrder
+	if err := Bind(jsonReq, &fromJSON); err != nil {
+		t.Fatalf("JSON binding failed with error: %v", err)
+	}
+	if err := Bind(formReq, &fromForm); err != nil {
+		t.Fatalf("Form binding failed with error: %v", err)
+	}
+	if !reflect.DeepEqual(fromJSON, fromForm) {
+		t.Errorf("Expected form and JSON to bind alike, got %+v and %+v", fromForm, fromJSON)
+	}
+}
+
+func TestBindNestedQuery(t *testing.T) {
+	type filter struct {
+		Status string `query:"status"`
+		MinAge int    `query:"min_age"`
+	}
+	type sort struct {
+		Field string `query:"field"`
+		Desc  bool   `query:"desc"`
+	}
+	type params struct {
+		Filter filter `query:"filter"`
+		Sort   []sort `query:"sort"`
+		Page   int    `query:"page"`
+		Flat   string `query:"legacy.key"`
+	}
+
+	r := httptest.NewRequest("GET", "/users?filter[status]=active&filter.min_age=18&sort[0][field]=name&sort[1][field]=age&sort[1][desc]=true&page=2&legacy.key=x", nil)
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.Filter != (filter{"active", 18}) {
+		t.Errorf("Unexpected filter %+v", p.Filter)
+	}
+	if len(p.Sort) != 2 || p.Sort[0] != (sort{"name", false}) || p.Sort[1] != (sort{"age", true}) {
+		t.Errorf("Unexpected sort %+v", p.Sort)
+	}
+	if p.Page != 2 {
+		t.Errorf("Expected Page to be 2, got %d", p.Page)
+	}
+	if p.Flat != "x" {
+		t.Errorf("Expected Flat to be 'x', got '%s'", p.Flat)
+	}
+}
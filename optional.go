@@ -0,0 +1,172 @@
+package binder
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// Optional holds a field that may be absent from a request, sent as null or
+// sent with a value, the three states a PATCH handler needs to tell apart.
+// Bind leaves an absent field's Optional zero, sets Null for an explicit
+// null and otherwise converts the value into Value as it would for a T.
+//
+// Example:
+//
+//	type UpdateUserRequest struct {
+//	    Name  binder.Optional[string]   `body:"name"`
+//	    Tags  binder.Optional[[]string] `body:"tags"`
+//	}
+//
+//	if name, ok := req.Name.Get(); ok {
+//	    user.Name = name
+//	}
+//	if req.Tags.Null {
+//	    user.Tags = nil
+//	}
+type Optional[T any] struct {
+	Value T
+	Set   bool // the field was present, with a value or null
+	Null  bool // the field was present as null
+}
+
+// Some returns an Optional holding v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// Get returns the value and whether one was sent. It reports false for both
+// absent and null fields.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set && !o.Null
+}
+
+// IsAbsent reports whether the field was missing from the request
+func (o Optional[T]) IsAbsent() bool {
+	return !o.Set
+}
+
+// IsNull reports whether the field was sent as null
+func (o Optional[T]) IsNull() bool {
+	return o.Set && o.Null
+}
+
+// MarshalJSON encodes the value, or null if the field is absent or null
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if v, ok := o.Get(); ok {
+		return json.Marshal(v)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON decodes a value or null. Fields missing from the JSON keep
+// the zero Optional, so they stay absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	*o = Optional[T]{Set: true}
+	if string(data) == "null" {
+		o.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+// optionalField is implemented by *Optional[T] so setField can bind it
+// without knowing T
+type optionalField interface {
+	setOptional(value interface{}, set func(field reflect.Value, value interface{}) error) error
+}
+
+// setOptional marks the Optional present and converts a non-null value
+// into its Value using set
+func (o *Optional[T]) setOptional(value interface{}, set func(reflect.Value, interface{}) error) error {
+	*o = Optional[T]{Set: true}
+	if value == nil {
+		o.Null = true
+		return nil
+	}
+	return set(reflect.ValueOf(&o.Value).Elem(), value)
+}
+
+// optionalFieldType is the interface implemented by pointers to Optionals
+var optionalFieldType = reflect.TypeOf((*optionalField)(nil)).Elem()
+
+// isOptionalType reports whether t is an Optional
+func isOptionalType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(optionalFieldType)
+}
+
+// asOptional returns the optionalField for field if it holds an Optional
+func asOptional(field reflect.Value) (optionalField, bool) {
+	if field.Kind() != reflect.Struct || !field.CanAddr() {
+		return nil, false
+	}
+	o, ok := field.Addr().Interface().(optionalField)
+	return o, ok
+}
+
+// Presence records which wire keys a request carried. A struct field of
+// this type, which needs no tag, is filled in by Bind with the key of every
+// field that was present in any source, including those sent as null or
+// skipped by omitempty. Keys of nested body objects are recorded as dotted
+// paths such as "address.street".
+//
+// Example:
+//
+//	type UpdateUserRequest struct {
+//	    Nickname string `body:"nickname"`
+//	    Present  binder.Presence
+//	}
+//
+//	if req.Present.Has("nickname") {
+//	    user.Nickname = req.Nickname // "" clears it
+//	}
+type Presence struct {
+	keys    map[string]bool
+	sources map[string]string
+}
+
+// presenceType is the reflect.Type of Presence
+var presenceType = reflect.TypeOf(Presence{})
+
+// Has reports whether the request carried the given wire key
+func (p Presence) Has(key string) bool {
+	return p.keys[key]
+}
+
+// Keys returns the wire keys the request carried, sorted
+func (p Presence) Keys() []string {
+	keys := make([]string, 0, len(p.keys))
+	for k := range p.keys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Source returns the source a field was bound from, such as "query" or
+// "header", given its Go field name, or "" if the field was absent. It
+// tells which source of a bind chain supplied the value.
+func (p Presence) Source(field string) string {
+	return p.sources[field]
+}
+
+// add records key, and for an object value the dotted paths of its keys
+func (p *Presence) add(key string, value interface{}) {
+	if p.keys == nil {
+		p.keys = make(map[string]bool)
+	}
+	p.keys[key] = true
+	if m, ok := value.(map[string]interface{}); ok {
+		for k, v := range m {
+			p.add(key+"."+k, v)
+		}
+	}
+}
+
+// setSource records the source a field was bound from
+func (p *Presence) setSource(field, source string) {
+	if p.sources == nil {
+		p.sources = make(map[string]string)
+	}
+	p.sources[field] = source
+}
@@ -0,0 +1,182 @@
+package binder
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type patchAddress struct {
+	Street string `body:"street"`
+	City   string `body:"city"`
+}
+
+type patchRequest struct {
+	ID      int                      `path:"id"`
+	Name    Optional[string]         `body:"name"`
+	Age     Optional[int]            `body:"age"`
+	Tags    Optional[[]string]       `body:"tags"`
+	Address Optional[patchAddress]   `body:"address"`
+	Email   *string                  `body:"email"`
+	Notify  Optional[bool]           `query:"notify"`
+	Extra   Optional[map[string]int] `body:"extra"`
+	Present Presence
+}
+
+func bindPatch(t *testing.T, target, body string) patchRequest {
+	t.Helper()
+	r := httptest.NewRequest("PATCH", target, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.SetPathValue("id", "7")
+
+	var req patchRequest
+	if err := Bind(r, &req); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	return req
+}
+
+func TestBindOptional(t *testing.T) {
+	req := bindPatch(t, "/users/7?notify=true", `{"name": "Alice", "age": null, "address": {"city": "Springfield"}, "email": null}`)
+
+	if name, ok := req.Name.Get(); !ok || name != "Alice" {
+		t.Errorf("Expected Name to be set to 'Alice', got %+v", req.Name)
+	}
+	if !req.Age.IsNull() || req.Age.IsAbsent() {
+		t.Errorf("Expected Age to be null, got %+v", req.Age)
+	}
+	if _, ok := req.Age.Get(); ok {
+		t.Error("Expected Get to report false for a null field")
+	}
+	if !req.Tags.IsAbsent() {
+		t.Errorf("Expected Tags to be absent, got %+v", req.Tags)
+	}
+	if addr, ok := req.Address.Get(); !ok || addr.City != "Springfield" || addr.Street != "" {
+		t.Errorf("Unexpected address %+v", req.Address)
+	}
+	if notify, ok := req.Notify.Get(); !ok || !notify {
+		t.Errorf("Expected Notify to be true, got %+v", req.Notify)
+	}
+	if !req.Extra.IsAbsent() {
+		t.Errorf("Expected Extra to be absent, got %+v", req.Extra)
+	}
+}
+
+func TestBindOptionalValues(t *testing.T) {
+	req := bindPatch(t, "/users/7", `{"age": 0, "tags": [], "extra": {"a": 1}}`)
+
+	if age, ok := req.Age.Get(); !ok || age != 0 {
+		t.Errorf("Expected Age to be set to 0, got %+v", req.Age)
+	}
+	if tags, ok := req.Tags.Get(); !ok || len(tags) != 0 {
+		t.Errorf("Expected Tags to be set and empty, got %+v", req.Tags)
+	}
+	if extra, ok := req.Extra.Get(); !ok || extra["a"] != 1 {
+		t.Errorf("Expected Extra to be set, got %+v", req.Extra)
+	}
+}
+
+func TestBindOptionalOmitEmpty(t *testing.T) {
+	type request struct {
+		Nick    Optional[string] `body:"nick,omitempty"`
+		Bio     Optional[string] `body:"bio,omitempty"`
+		Present Presence
+	}
+
+	r := httptest.NewRequest("PATCH", "/", strings.NewReader(`{"nick": null, "bio": ""}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	var req request
+	if err := Bind(r, &req); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if !req.Nick.IsNull() || !req.Present.Has("nick") {
+		t.Errorf("Expected Nick to be null and present, got %+v", req.Nick)
+	}
+	if bio, ok := req.Bio.Get(); !ok || bio != "" {
+		t.Errorf("Expected Bio to be set to an empty string, got %+v", req.Bio)
+	}
+}
+
+func TestShouldOmitFieldOptional(t *testing.T) {
+	typ := reflect.TypeOf(struct {
+		Nick Optional[string] `body:"nick,omitempty"`
+		Name string           `body:"name,omitempty"`
+	}{})
+
+	nick, name := typ.Field(0), typ.Field(1)
+	if shouldOmitField(nick, nil) {
+		t.Error("Expected an Optional field never to be omitted")
+	}
+	if !shouldOmitField(name, "") {
+		t.Error("Expected an empty string field to be omitted")
+	}
+	if allocs := testing.AllocsPerRun(10, func() { shouldOmitField(nick, "") }); allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func TestBindOptionalConversionError(t *testing.T) {
+	r := httptest.NewRequest("PATCH", "/users/7", strings.NewReader(`{"age": "old"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	var req patchRequest
+	if err := Bind(r, &req); err == nil || !strings.Contains(err.Error(), "Age") {
+		t.Errorf("Expected conversion error for Age, got %v", err)
+	}
+}
+
+func TestPresence(t *testing.T) {
+	req := bindPatch(t, "/users/7?notify=1", `{"name": "Alice", "email": null, "address": {"city": "Springfield"}, "unbound": 1}`)
+
+	want := []string{"address", "address.city", "email", "id", "name", "notify"}
+	if got := req.Present.Keys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected keys %v, got %v", want, got)
+	}
+	if !req.Present.Has("email") {
+		t.Error("Expected null email to be present")
+	}
+	if req.Present.Has("age") || req.Present.Has("unbound") {
+		t.Error("Expected absent and unbound keys not to be present")
+	}
+
+	// A second bind starts afresh
+	r := httptest.NewRequest("PATCH", "/users/7", strings.NewReader(`{"age": 3}`))
+	r.Header.Set("Content-Type", "application/json")
+	if err := Bind(r, &req); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if got := req.Present.Keys(); !reflect.DeepEqual(got, []string{"age"}) {
+		t.Errorf("Expected keys [age], got %v", got)
+	}
+}
+
+func TestOptionalJSON(t *testing.T) {
+	var v struct {
+		A Optional[int]    `json:"a"`
+		B Optional[int]    `json:"b"`
+		C Optional[string] `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a": 1, "b": null}`), &v); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if a, ok := v.A.Get(); !ok || a != 1 {
+		t.Errorf("Expected A to be 1, got %+v", v.A)
+	}
+	if !v.B.IsNull() || !v.C.IsAbsent() {
+		t.Errorf("Expected B null and C absent, got %+v and %+v", v.B, v.C)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"a":1,"b":null,"c":null}` {
+		t.Errorf("Unexpected JSON %s", data)
+	}
+	if o := Some("x"); !o.Set || o.Value != "x" {
+		t.Errorf("Unexpected Some result %+v", o)
+	}
+}
@@ -0,0 +1,235 @@
+package binder
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BindOptions configures the behaviour of BindWithOptions.
+//
+// The zero value is valid and binds exactly as Bind does.
+type BindOptions struct {
+	// TimeLocation is used when parsing time.Time values that carry no
+	// zone information of their own. Defaults to time.UTC.
+	TimeLocation *time.Location
+
+	// DurationUnit is the unit applied to numeric time.Duration values,
+	// e.g. time.Second turns 30 into 30s. Defaults to time.Nanosecond.
+	DurationUnit time.Duration
+
+	// TrueValues and FalseValues extend the strings accepted for bool
+	// fields beyond those understood by strconv.ParseBool, e.g. "on" and
+	// "yes" for HTML checkboxes. Matching is case-insensitive.
+	TrueValues  []string
+	FalseValues []string
+
+	// QueryFlags binds a query parameter that is present without a value,
+	// such as ?verbose, as true for bool fields.
+	QueryFlags bool
+
+	// NumberLiterals enables Go integer literal syntax for integer fields:
+	// 0x, 0o and 0b prefixes and underscore digit separators.
+	NumberLiterals bool
+
+	// ThousandsSeparator, when set, is accepted between groups of three
+	// digits in integer fields, e.g. "," for "1,000,000".
+	ThousandsSeparator string
+
+	// RejectUnknownMediaType makes binding fail with ErrUnsupportedMediaType
+	// when a request has a body whose Content-Type has no registered
+	// Decoder or whose Content-Encoding is not gzip or deflate. By default
+	// such bodies are ignored.
+	RejectUnknownMediaType bool
+
+	// RejectUnknownFields makes binding a struct fail with an
+	// UnknownFieldsError, matching ErrUnknownField, when the query string
+	// or body carries keys that no field binds, including keys of nested
+	// objects. By default unknown keys are ignored.
+	RejectUnknownFields bool
+
+	// KeyMatching controls how body, form and query keys are matched to
+	// tag names. MatchCaseInsensitive accepts Email for `body:"email"` and
+	// MatchNormalized also accepts page_size, pageSize and page-size for
+	// one another. Defaults to MatchExact.
+	KeyMatching KeyMatching
+
+	// Naming derives the key of a field whose bind tag names a source
+	// without one, such as `bind:"query"`, or that binds from its struct's
+	// default source. NameSnakeCase binds PageSize from page_size.
+	// Defaults to NameExact, the Go field name.
+	Naming NamingStrategy
+
+	// Tags renames or disables the struct tags binder reads, e.g. uri
+	// instead of path, or no json tags at all. Defaults to the standard
+	// names.
+	Tags TagNames
+
+	// StrictCharset rejects bodies whose charset parameter names an
+	// unsupported charset, with ErrUnsupportedMediaType, and bodies that
+	// are not valid UTF-8 after conversion, with ErrInvalidUTF8. By default
+	// unknown charsets are treated as UTF-8 and invalid bytes pass through.
+	StrictCharset bool
+
+	// MaxBodySize limits the number of request body bytes read, like
+	// http.MaxBytesReader. Larger bodies fail with ErrBodyTooLarge. Zero
+	// means DefaultMaxBodySize and a negative value disables the limit.
+	MaxBodySize int64
+
+	// MaxDecompressedSize limits the size of a gzip or deflate encoded body
+	// after decompression, guarding against decompression bombs. Zero means
+	// DefaultMaxDecompressedSize and a negative value disables the limit.
+	MaxDecompressedSize int64
+
+	// MaxDepth, MaxArrayLength and MaxKeys cap the nesting depth, the
+	// length of any array and the number of keys in any object of a
+	// decoded body. They are enforced while JSON is decoded and again when
+	// slices are built, failing with ErrBodyTooDeep, ErrArrayTooLong and
+	// ErrTooManyKeys. Zero means no limit.
+	MaxDepth       int
+	MaxArrayLength int
+	MaxKeys        int
+
+	// SpoolThreshold is the body size above which the request body is
+	// copied to a temporary file rather than held in memory. The file keeps
+	// r.Body re-readable and is removed when the request's context is done
+	// or r.Body is closed. Zero keeps every body in memory.
+	SpoolThreshold int64
+
+	// SpoolDir is the directory for spooled bodies. Empty means os.TempDir.
+	SpoolDir string
+
+	// NullValues controls how an explicit null binds to a field that cannot
+	// hold nil, such as a string or int. Pointers, slices, maps and
+	// interfaces are always reset to nil. The default keeps the field's
+	// current value.
+	NullValues NullPolicy
+
+	// keyIndexes caches folded object keys for lookupKey. It is only
+	// written with key matching other than MatchExact, by calls that work
+	// on their own copy of the options.
+	keyIndexes keyIndexCache
+}
+
+// defaultOptions are the options of the package-level functions. They are
+// shared by every call, which is safe as exact key matching never writes
+// to them.
+var defaultOptions BindOptions
+
+// NullPolicy is how an explicit null binds to a non-nullable field
+type NullPolicy int
+
+const (
+	NullKeep   NullPolicy = iota // leave the field unchanged, as if absent
+	NullZero                     // reset the field to its zero value
+	NullReject                   // fail with ErrNullValue
+)
+
+// ErrNullValue is returned for a null sent to a non-nullable field when
+// BindOptions.NullValues is NullReject
+var ErrNullValue = errors.New("null value for non-nullable field")
+
+// location returns the configured time location or UTC
+func (o *BindOptions) location() *time.Location {
+	if o.TimeLocation == nil {
+		return time.UTC
+	}
+	return o.TimeLocation
+}
+
+// durationUnit returns the configured duration unit or nanoseconds
+func (o *BindOptions) durationUnit() time.Duration {
+	if o.DurationUnit <= 0 {
+		return time.Nanosecond
+	}
+	return o.DurationUnit
+}
+
+// maxBodySize returns the configured body size limit
+func (o *BindOptions) maxBodySize() int64 {
+	if o.MaxBodySize == 0 {
+		return DefaultMaxBodySize
+	}
+	return o.MaxBodySize
+}
+
+// maxDecompressedSize returns the configured decompressed size limit
+func (o *BindOptions) maxDecompressedSize() int64 {
+	if o.MaxDecompressedSize == 0 {
+		return DefaultMaxDecompressedSize
+	}
+	return o.MaxDecompressedSize
+}
+
+// parseBool parses s using the configured vocabularies, falling back to
+// strconv.ParseBool
+func (o *BindOptions) parseBool(s string) (bool, error) {
+	for _, t := range o.TrueValues {
+		if strings.EqualFold(s, t) {
+			return true, nil
+		}
+	}
+	for _, f := range o.FalseValues {
+		if strings.EqualFold(s, f) {
+			return false, nil
+		}
+	}
+	return strconv.ParseBool(s)
+}
+
+// numberFormat prepares an integer string for strconv according to the
+// enabled numeric formats, returning the cleaned string and the base to use
+func (o *BindOptions) numberFormat(s string) (string, int, error) {
+	if o.ThousandsSeparator != "" && strings.Contains(s, o.ThousandsSeparator) {
+		stripped, err := stripThousands(s, o.ThousandsSeparator)
+		if err != nil {
+			return "", 0, err
+		}
+		s = stripped
+	}
+	if o.NumberLiterals {
+		digits := strings.TrimLeft(s, "+-")
+		if len(digits) > 1 && digits[0] == '0' && strings.ContainsRune("xXoObB", rune(digits[1])) {
+			return s, 0, nil
+		}
+		// Without a prefix the digits are decimal, so a leading 0 is not
+		// read as a legacy octal literal
+		stripped, err := stripUnderscores(s)
+		if err != nil {
+			return "", 0, err
+		}
+		s = stripped
+	}
+	return s, 10, nil
+}
+
+// stripUnderscores removes underscore digit separators from s, requiring
+// that each one sits between two digits
+func stripUnderscores(s string) (string, error) {
+	digits := strings.TrimLeft(s, "+-")
+	groups := strings.Split(digits, "_")
+	for _, g := range groups {
+		if g == "" {
+			return "", fmt.Errorf("invalid digit separator in %q", s)
+		}
+	}
+	return s[:len(s)-len(digits)] + strings.Join(groups, ""), nil
+}
+
+// stripThousands removes sep from s, requiring that every group after the
+// first has exactly three digits
+func stripThousands(s, sep string) (string, error) {
+	digits := strings.TrimLeft(s, "+-")
+	groups := strings.Split(digits, sep)
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return "", fmt.Errorf("invalid digit grouping in %q", s)
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return "", fmt.Errorf("invalid digit grouping in %q", s)
+		}
+	}
+	return s[:len(s)-len(digits)] + strings.Join(groups, ""), nil
+}
@@ -0,0 +1,143 @@
+package binder
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestBindBoolVocabulary(t *testing.T) {
+	form := url.Values{}
+	form.Add("subscribe", "on")
+	form.Add("terms", "Yes")
+	form.Add("marketing", "off")
+
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	type params struct {
+		Subscribe bool `body:"subscribe"`
+		Terms     bool `body:"terms"`
+		Marketing bool `body:"marketing"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err == nil {
+		t.Fatalf("Binding should fail for \"on\" without a configured vocabulary")
+	}
+
+	opts := BindOptions{
+		TrueValues:  []string{"on", "yes"},
+		FalseValues: []string{"off", "no"},
+	}
+	p = params{Marketing: true}
+	if err := BindWithOptions(r, &p, opts); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+
+	if !p.Subscribe || !p.Terms || p.Marketing {
+		t.Errorf("Expected Subscribe and Terms true and Marketing false, got %+v", p)
+	}
+}
+
+func TestBindQueryFlags(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?verbose&debug=&name", nil)
+
+	type params struct {
+		Verbose bool   `query:"verbose"`
+		Debug   *bool  `query:"debug"`
+		Quiet   bool   `query:"quiet"`
+		Name    string `query:"name"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.Verbose || p.Debug != nil {
+		t.Errorf("Expected flags to be ignored without QueryFlags, got %+v", p)
+	}
+
+	p = params{}
+	if err := BindWithOptions(r, &p, BindOptions{QueryFlags: true}); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if !p.Verbose {
+		t.Errorf("Expected Verbose to be true")
+	}
+	if p.Debug == nil || !*p.Debug {
+		t.Errorf("Expected Debug to be true, got %v", p.Debug)
+	}
+	if p.Quiet {
+		t.Errorf("Expected absent Quiet to stay false")
+	}
+	if p.Name != "" {
+		t.Errorf("Expected Name to stay empty, got %q", p.Name)
+	}
+}
+
+func TestBindNumberFormats(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?mask=0xff&big=1_000_000&perm=0o755&count=1,234,567&neg=-12,000", nil)
+
+	type params struct {
+		Mask  uint8 `query:"mask"`
+		Big   int   `query:"big"`
+		Perm  int   `query:"perm"`
+		Count int   `query:"count"`
+		Neg   int   `query:"neg"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err == nil {
+		t.Fatalf("Binding should fail for hex input without NumberLiterals")
+	}
+
+	opts := BindOptions{NumberLiterals: true, ThousandsSeparator: ","}
+	p = params{}
+	if err := BindWithOptions(r, &p, opts); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+
+	if p.Mask != 255 || p.Big != 1000000 || p.Perm != 0755 || p.Count != 1234567 || p.Neg != -12000 {
+		t.Errorf("Unexpected values: %+v", p)
+	}
+}
+
+func TestBindNumberLiteralsDecimal(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?a=010&b=08&c=0_10&d=-0_9", nil)
+
+	type params struct {
+		A int `query:"a"`
+		B int `query:"b"`
+		C int `query:"c"`
+		D int `query:"d"`
+	}
+
+	var p params
+	if err := BindWithOptions(r, &p, BindOptions{NumberLiterals: true}); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.A != 10 || p.B != 8 || p.C != 10 || p.D != -9 {
+		t.Errorf("Expected {10 8 10 -9}, got %+v", p)
+	}
+
+	r = httptest.NewRequest("GET", "/test?a=1__0", nil)
+	if err := BindWithOptions(r, &p, BindOptions{NumberLiterals: true}); err == nil {
+		t.Errorf("Binding should fail for misplaced digit separators")
+	}
+}
+
+func TestBindInvalidThousandsGrouping(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?count=1,23", nil)
+
+	type params struct {
+		Count int `query:"count"`
+	}
+
+	var p params
+	err := BindWithOptions(r, &p, BindOptions{ThousandsSeparator: ","})
+	if err == nil || !strings.Contains(err.Error(), "invalid digit grouping") {
+		t.Errorf("Expected digit grouping error, got: %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package binder
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7386) request body to an
+// existing value, typically one just loaded from storage. Keys are mapped to
+// fields with the same body, json and xml tags as BindStruct. Keys present
+// in the patch overwrite their fields, keys sent as null reset their fields
+// to the zero value, objects are merged into nested structs and maps, and
+// keys absent from the patch leave their fields untouched. Arrays replace
+// slices wholesale, as the RFC requires.
+//
+// The body must be a JSON object sent as application/merge-patch+json or
+// another JSON media type. If the target implements Validator, Validate is
+// called on the patched value.
+//
+// Example:
+//
+//	user, err := store.Get(id)
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if err := binder.ApplyMergePatch(r, &user); err != nil {
+//	    // Handle patch error
+//	}
+//	store.Put(user)
+func ApplyMergePatch(r *http.Request, i interface{}) error {
+	return applyMergePatch(r, i, &defaultOptions)
+}
+
+// ApplyMergePatchWithOptions behaves like ApplyMergePatch but applies the
+// given options to this call
+func ApplyMergePatchWithOptions(r *http.Request, i interface{}, opts BindOptions) error {
+	return applyMergePatch(r, i, &opts)
+}
+
+// applyMergePatch implements ApplyMergePatchWithOptions for options that
+// may be shared, as bindWithOptions does
+func applyMergePatch(r *http.Request, i interface{}, opts *BindOptions) error {
+	val := reflect.ValueOf(i)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return errors.New("merge patch target must be a non-nil pointer")
+	}
+
+	doc, err := decodeJSONBody(r, opts)
+	if err != nil {
+		return err
+	}
+	patch, ok := doc.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("merge patch must be a JSON object, got %T", doc)
+	}
+
+	if err := mergeValue(val.Elem(), patch, "", opts); err != nil {
+		return err
+	}
+
+	if validator, ok := i.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// decodeJSONBody buffers and decodes a request body that must be JSON,
+// such as a patch document. Unlike parseRequestBody it reports malformed
+// and empty bodies rather than ignoring them.
+func decodeJSONBody(r *http.Request, opts *BindOptions) (interface{}, error) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if mediaType, _ := parseMediaType(ct); !isJSONMediaType(mediaType) {
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, mediaType)
+		}
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, errors.New("request body is empty")
+	}
+
+	body, size, err := bufferBody(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, errors.New("request body is empty")
+	}
+	decompressed, err := decompressBody(body, r.Header.Values("Content-Encoding"), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := jsonDecoder{}.decodeValue(decompressed, opts)
+	if isFatalBodyError(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JSON body: %w", err)
+	}
+	return doc, nil
+}
+
+// mergeValue merges a patch value into field following RFC 7386
+func mergeValue(field reflect.Value, patch interface{}, tag reflect.StructTag, opts *BindOptions) error {
+	if patch == nil {
+		// An Optional records the null, so it reads as null rather than absent
+		if o, ok := asOptional(field); ok {
+			return o.setOptional(nil, nil)
+		}
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	object, isObject := patch.(map[string]interface{})
+	if !isObject || isMergeLeaf(field) {
+		return setField(field, patch, tag, opts)
+	}
+
+	switch field.Kind() {
+	case reflect.Struct:
+		return mergeStruct(field, object, opts)
+
+	case reflect.Ptr:
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return mergeValue(field.Elem(), patch, tag, opts)
+
+	case reflect.Map:
+		if field.Type().Key().Kind() != reflect.String {
+			return setField(field, patch, tag, opts)
+		}
+		return mergeMap(field, object, tag, opts)
+
+	case reflect.Interface:
+		if field.NumMethod() != 0 {
+			break
+		}
+		if existing, ok := field.Interface().(map[string]interface{}); ok && existing != nil {
+			field.Set(reflect.ValueOf(mergeObjects(existing, object)))
+			return nil
+		}
+		field.Set(reflect.ValueOf(mergeObjects(make(map[string]interface{}), object)))
+		return nil
+	}
+	return setField(field, patch, tag, opts)
+}
+
+// isMergeLeaf reports whether field is set as a whole rather than merged,
+// as times, Optionals and types with their own text decoding are
+func isMergeLeaf(field reflect.Value) bool {
+	t := field.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return true
+	}
+	if _, ok := asOptional(field); ok {
+		return true
+	}
+	textUnmarshaler := reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	return t.Implements(textUnmarshaler) || reflect.PointerTo(t).Implements(textUnmarshaler)
+}
+
+// mergeStruct merges a patch object into a struct field by field
+func mergeStruct(field reflect.Value, patch map[string]interface{}, opts *BindOptions) error {
+	for i, fieldType := range structFields(field.Type(), opts.Tags) {
+		if !fieldType.IsExported() {
+			continue
+		}
+		value, ok := lookupBodyField(patch, fieldType, opts)
+		if !ok {
+			continue
+		}
+		if err := mergeValue(field.Field(i), value, fieldType.Tag, opts); err != nil {
+			return fmt.Errorf("error merging field %s: %w", fieldType.Name, err)
+		}
+	}
+	return nil
+}
+
+// mergeMap merges a patch object into a map with string keys, deleting
+// keys sent as null
+func mergeMap(field reflect.Value, patch map[string]interface{}, tag reflect.StructTag, opts *BindOptions) error {
+	if err := opts.checkKeys(len(patch)); err != nil {
+		return err
+	}
+	if field.IsNil() {
+		field.Set(reflect.MakeMapWithSize(field.Type(), len(patch)))
+	}
+
+	keyType := field.Type().Key()
+	for k, v := range patch {
+		key := reflect.ValueOf(k).Convert(keyType)
+		if v == nil {
+			field.SetMapIndex(key, reflect.Value{})
+			continue
+		}
+
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if existing := field.MapIndex(key); existing.IsValid() {
+			elem.Set(existing)
+		}
+		if err := mergeValue(elem, v, tag, opts); err != nil {
+			return fmt.Errorf("error merging map key %q: %w", k, err)
+		}
+		field.SetMapIndex(key, elem)
+	}
+	return nil
+}
+
+// mergeObjects applies a patch to decoded JSON data as RFC 7386 describes
+func mergeObjects(target, patch map[string]interface{}) map[string]interface{} {
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			target[k] = v
+			continue
+		}
+		existing, ok := target[k].(map[string]interface{})
+		if !ok {
+			existing = make(map[string]interface{})
+		}
+		target[k] = mergeObjects(existing, object)
+	}
+	return target
+}
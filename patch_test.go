@@ -0,0 +1,180 @@
+package binder
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mergeAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type mergeUser struct {
+	Name     string                 `body:"name"`
+	Email    string                 `json:"email"`
+	Age      int                    `json:"age"`
+	Nickname *string                `json:"nickname"`
+	Tags     []string               `json:"tags"`
+	Address  mergeAddress           `json:"address"`
+	Billing  *mergeAddress          `json:"billing"`
+	Labels   map[string]string      `json:"labels"`
+	Meta     map[string]interface{} `json:"meta"`
+	Seen     time.Time              `json:"seen"`
+	internal string
+}
+
+func newMergePatchRequest(body string) *http.Request {
+	r := httptest.NewRequest("PATCH", "/users/1", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/merge-patch+json")
+	return r
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	nickname := "Al"
+	user := mergeUser{
+		Name:     "Alice",
+		Email:    "alice@example.com",
+		Age:      30,
+		Nickname: &nickname,
+		Tags:     []string{"a", "b"},
+		Address:  mergeAddress{Street: "Main St", City: "Springfield"},
+		Labels:   map[string]string{"team": "core", "tier": "gold"},
+		Meta:     map[string]interface{}{"prefs": map[string]interface{}{"theme": "dark", "lang": "en"}},
+		internal: "kept",
+	}
+
+	r := newMergePatchRequest(`{
+		"name": "Alicia",
+		"age": null,
+		"nickname": null,
+		"tags": ["c"],
+		"address": {"city": "Shelbyville"},
+		"billing": {"street": "Elm St", "city": null},
+		"labels": {"tier": null, "region": "eu"},
+		"meta": {"prefs": {"lang": null, "tz": "UTC"}},
+		"seen": "2024-01-02T03:04:05Z",
+		"unknown": 1
+	}`)
+	if err := ApplyMergePatch(r, &user); err != nil {
+		t.Fatalf("ApplyMergePatch failed with error: %v", err)
+	}
+
+	if user.Name != "Alicia" {
+		t.Errorf("Expected Name to be 'Alicia', got '%s'", user.Name)
+	}
+	if user.Email != "alice@example.com" {
+		t.Errorf("Expected Email to be untouched, got '%s'", user.Email)
+	}
+	if user.Age != 0 || user.Nickname != nil {
+		t.Errorf("Expected Age and Nickname to be cleared, got %d and %v", user.Age, user.Nickname)
+	}
+	if !reflect.DeepEqual(user.Tags, []string{"c"}) {
+		t.Errorf("Expected Tags to be replaced, got %v", user.Tags)
+	}
+	if user.Address != (mergeAddress{Street: "Main St", City: "Shelbyville"}) {
+		t.Errorf("Expected Address to be merged, got %+v", user.Address)
+	}
+	if user.Billing == nil || *user.Billing != (mergeAddress{Street: "Elm St"}) {
+		t.Errorf("Expected Billing to be created, got %+v", user.Billing)
+	}
+	if !reflect.DeepEqual(user.Labels, map[string]string{"team": "core", "region": "eu"}) {
+		t.Errorf("Expected Labels to be merged, got %v", user.Labels)
+	}
+	wantMeta := map[string]interface{}{"prefs": map[string]interface{}{"theme": "dark", "tz": "UTC"}}
+	if !reflect.DeepEqual(user.Meta, wantMeta) {
+		t.Errorf("Expected Meta to be merged, got %v", user.Meta)
+	}
+	if !user.Seen.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected Seen %v", user.Seen)
+	}
+	if user.internal != "kept" {
+		t.Errorf("Expected unexported field to be untouched, got '%s'", user.internal)
+	}
+}
+
+func TestApplyMergePatchClearsNested(t *testing.T) {
+	user := mergeUser{
+		Address: mergeAddress{Street: "Main St", City: "Springfield"},
+		Billing: &mergeAddress{Street: "Elm St"},
+	}
+	r := newMergePatchRequest(`{"address": null, "billing": null}`)
+	if err := ApplyMergePatch(r, &user); err != nil {
+		t.Fatalf("ApplyMergePatch failed with error: %v", err)
+	}
+	if user.Address != (mergeAddress{}) || user.Billing != nil {
+		t.Errorf("Expected nested values to be cleared, got %+v and %+v", user.Address, user.Billing)
+	}
+}
+
+func TestApplyMergePatchNullOptional(t *testing.T) {
+	type profile struct {
+		Nick Optional[string] `json:"nick"`
+		Bio  Optional[string] `json:"bio"`
+	}
+
+	p := profile{Nick: Some("Al"), Bio: Some("Hi")}
+	r := newMergePatchRequest(`{"nick": null}`)
+	if err := ApplyMergePatch(r, &p); err != nil {
+		t.Fatalf("ApplyMergePatch failed with error: %v", err)
+	}
+	if !p.Nick.IsNull() {
+		t.Errorf("Expected Nick to be null, got %+v", p.Nick)
+	}
+	if p.Bio != Some("Hi") {
+		t.Errorf("Expected Bio to be untouched, got %+v", p.Bio)
+	}
+}
+
+type validatedMergeUser struct {
+	Name string `json:"name"`
+}
+
+func (u *validatedMergeUser) Validate() error {
+	if u.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func TestApplyMergePatchErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		target      interface{}
+		wantErr     error
+		contains    string
+	}{
+		{"NotObject", "application/merge-patch+json", `["a"]`, &mergeUser{}, nil, "must be a JSON object"},
+		{"Malformed", "application/merge-patch+json", `{"name": `, &mergeUser{}, nil, "failed to decode JSON body"},
+		{"Empty", "application/merge-patch+json", ``, &mergeUser{}, nil, "request body is empty"},
+		{"NotJSON", "application/x-www-form-urlencoded", `name=x`, &mergeUser{}, ErrUnsupportedMediaType, ""},
+		{"Conversion", "application/json", `{"age": "old"}`, &mergeUser{}, nil, "error merging field Age"},
+		{"Validation", "application/json", `{"name": null}`, &validatedMergeUser{Name: "A"}, nil, "validation failed"},
+		{"NotPointer", "application/json", `{}`, mergeUser{}, nil, "non-nil pointer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("PATCH", "/users/1", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+
+			err := ApplyMergePatch(r, tt.target)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.contains != "" && !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("Expected error containing %q, got %v", tt.contains, err)
+			}
+		})
+	}
+}
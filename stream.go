@@ -0,0 +1,203 @@
+package binder
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"reflect"
+)
+
+// streamMediaTypes are the media types Stream accepts besides JSON
+var streamMediaTypes = map[string]bool{
+	"application/x-ndjson":     true,
+	"application/ndjson":       true,
+	"application/jsonl":        true,
+	"application/x-jsonlines":  true,
+	"application/jsonlines":    true,
+	"application/json-lines":   true,
+	"application/x-json-lines": true,
+}
+
+// Stream decodes a request body holding many records and binds each one to
+// a T as it is read. The body may be a top-level JSON array or a sequence
+// of JSON values such as NDJSON; records are never buffered together, so
+// bodies of any length can be processed in memory bounded by the largest
+// record. Unlike Bind, no default body size limit applies.
+//
+// Struct records bind like Bind: body, json and xml tags read from the
+// record while path, query and cookie tags read from the request, and
+// Validate is called on types implementing Validator. Other types, such as
+// int or string, are converted from each record directly.
+//
+// A record that fails to bind or validate yields its error and the stream
+// continues. A malformed body yields its error and ends the stream.
+// Unlike Bind, Stream consumes r.Body rather than restoring it.
+//
+// Example:
+//
+//	for event, err := range binder.Stream[Event](r) {
+//	    if err != nil {
+//	        // Handle record error, or break to stop reading
+//	        continue
+//	    }
+//	    store(event)
+//	}
+func Stream[T any](r *http.Request) iter.Seq2[T, error] {
+	return StreamWithOptions[T](r, BindOptions{})
+}
+
+// StreamWithOptions behaves like Stream but applies the given options.
+// MaxBodySize and MaxDecompressedSize apply to the whole body, and only when
+// set: zero means no limit rather than the defaults Bind uses. MaxDepth and
+// MaxKeys apply to each record; MaxArrayLength applies within records but
+// not to the number of records.
+func StreamWithOptions[T any](r *http.Request, opts BindOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		dec, array, err := newStreamDecoder(r, &opts)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		if dec == nil {
+			return
+		}
+
+		for index := 0; ; index++ {
+			if array && !dec.More() {
+				if _, err := dec.Token(); err != nil { // closing bracket
+					yield(zero, fmt.Errorf("failed to decode record stream: %w", err))
+				}
+				return
+			}
+
+			record, err := decodeRecord(dec, &opts)
+			if !array && errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				if !isFatalBodyError(err) {
+					err = fmt.Errorf("failed to decode record %d: %w", index, err)
+				}
+				yield(zero, err)
+				return
+			}
+
+			var v T
+			if err := bindRecord(r, &v, record, &opts); err != nil {
+				if !yield(zero, fmt.Errorf("record %d: %w", index, err)) {
+					return
+				}
+				continue
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// newStreamDecoder prepares a JSON decoder positioned at the first record
+// and reports whether the records are elements of a top-level array. It
+// returns a nil decoder for an empty body.
+func newStreamDecoder(r *http.Request, opts *BindOptions) (*json.Decoder, bool, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false, nil
+	}
+
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _ := parseMediaType(ct)
+		if !isJSONMediaType(mediaType) && !streamMediaTypes[mediaType] {
+			return nil, false, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)
+		}
+	}
+
+	// Records are read one at a time, so the whole body is only limited
+	// when a limit is set
+	limit := opts.MaxBodySize
+	if limit > 0 && r.ContentLength > limit {
+		return nil, false, fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, limit)
+	}
+	var body io.Reader = r.Body
+	if limit > 0 {
+		body = http.MaxBytesReader(nil, r.Body, limit)
+	}
+	decompressOpts := *opts
+	if decompressOpts.MaxDecompressedSize == 0 {
+		decompressOpts.MaxDecompressedSize = -1
+	}
+	body, err := decompressBody(body, r.Header.Values("Content-Encoding"), &decompressOpts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Peek at the first significant byte to tell an array from a sequence
+	br := bufio.NewReader(body)
+	for {
+		c, err := br.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, bodyReadError(err)
+		}
+		if c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+			continue
+		}
+		br.UnreadByte()
+
+		dec := json.NewDecoder(br)
+		if c != '[' {
+			return dec, false, nil
+		}
+		_, err = dec.Token() // opening bracket
+		return dec, true, err
+	}
+}
+
+// decodeRecord decodes the next record, enforcing structural limits
+func decodeRecord(dec *json.Decoder, opts *BindOptions) (interface{}, error) {
+	var record interface{}
+	var err error
+	if opts.hasStructuralLimits() {
+		record, err = decodeJSONValue(dec, opts, 1)
+	} else {
+		err = dec.Decode(&record)
+	}
+
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return nil, bodyReadError(err)
+	}
+	return record, err
+}
+
+// bindRecord binds one decoded record into the value v points to
+func bindRecord(r *http.Request, v interface{}, record interface{}, opts *BindOptions) error {
+	// Indexes of earlier records are dropped so a long stream is not retained
+	opts.keyIndexes = nil
+	val := reflect.ValueOf(v).Elem()
+	if val.Kind() == reflect.Struct && val.Type() != timeType {
+		if opts.RejectUnknownFields {
+			if err := rejectUnknownFields(r, val.Type(), record, opts); err != nil {
+				return err
+			}
+		}
+		if err := bindStructFields(r, val.Type(), val, record, opts); err != nil {
+			return err
+		}
+	} else if err := setField(val, record, "", opts); err != nil {
+		return err
+	}
+
+	if validator, ok := v.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package binder
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type streamEvent struct {
+	Tenant string `query:"tenant"`
+	ID     int    `body:"id"`
+	Name   string `json:"name"`
+}
+
+func (e *streamEvent) Validate() error {
+	if e.ID <= 0 {
+		return errors.New("id must be positive")
+	}
+	return nil
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"NDJSON", "application/x-ndjson", "{\"id\": 1, \"name\": \"a\"}\n{\"id\": 2, \"name\": \"b\"}\n{\"id\": 3, \"name\": \"c\"}\n"},
+		{"JSONLines", "application/jsonl", `{"id": 1, "name": "a"} {"id": 2, "name": "b"}` + "\r\n" + `{"id": 3, "name": "c"}`},
+		{"Array", "application/json", ` [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}, {"id": 3, "name": "c"}]`},
+		{"NoContentType", "", `[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}, {"id": 3, "name": "c"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/events?tenant=acme", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			var events []streamEvent
+			for event, err := range Stream[streamEvent](r) {
+				if err != nil {
+					t.Fatalf("Stream failed with error: %v", err)
+				}
+				events = append(events, event)
+			}
+
+			if len(events) != 3 {
+				t.Fatalf("Expected 3 events, got %d", len(events))
+			}
+			for i, event := range events {
+				if event.ID != i+1 || event.Name != string(rune('a'+i)) || event.Tenant != "acme" {
+					t.Errorf("Unexpected event %d: %+v", i, event)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamRecordErrors(t *testing.T) {
+	body := "{\"id\": 1}\n{\"id\": 0}\n{\"id\": \"x\"}\n[1]\n{\"id\": 5}\n"
+	r := httptest.NewRequest("POST", "/events", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-ndjson")
+
+	var ids []int
+	var errs []error
+	for event, err := range Stream[streamEvent](r) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, event.ID)
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 5 {
+		t.Errorf("Expected ids [1 5], got %v", ids)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("Expected 3 errors, got %d: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "record 1: validation failed") {
+		t.Errorf("Unexpected validation error: %v", errs[0])
+	}
+	if !strings.HasPrefix(errs[1].Error(), "record 2:") || !strings.HasPrefix(errs[2].Error(), "record 3:") {
+		t.Errorf("Unexpected record errors: %v", errs[1:])
+	}
+}
+
+func TestStreamMalformed(t *testing.T) {
+	r := httptest.NewRequest("POST", "/events", strings.NewReader(`[{"id": 1}, {"id": `))
+	r.Header.Set("Content-Type", "application/json")
+
+	var count int
+	var lastErr error
+	for _, err := range Stream[streamEvent](r) {
+		count++
+		lastErr = err
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 results, got %d", count)
+	}
+	if lastErr == nil || !strings.Contains(lastErr.Error(), "failed to decode record 1") {
+		t.Errorf("Expected decode error for record 1, got %v", lastErr)
+	}
+}
+
+func TestStreamScalars(t *testing.T) {
+	r := httptest.NewRequest("POST", "/numbers", strings.NewReader(`[1, "2", 3.0]`))
+
+	var sum int
+	for n, err := range Stream[int](r) {
+		if err != nil {
+			t.Fatalf("Stream failed with error: %v", err)
+		}
+		sum += n
+	}
+	if sum != 6 {
+		t.Errorf("Expected sum to be 6, got %d", sum)
+	}
+}
+
+func TestStreamBreak(t *testing.T) {
+	r := httptest.NewRequest("POST", "/numbers", strings.NewReader("1\n2\n3\n"))
+
+	var seen []int
+	for n := range Stream[int](r) {
+		seen = append(seen, n)
+		if n == 2 {
+			break
+		}
+	}
+	if len(seen) != 2 {
+		t.Errorf("Expected iteration to stop after 2 records, got %v", seen)
+	}
+}
+
+func TestStreamEmptyBody(t *testing.T) {
+	r := httptest.NewRequest("POST", "/events", strings.NewReader("  \n"))
+	for _, err := range Stream[streamEvent](r) {
+		t.Errorf("Expected no records, got error %v", err)
+	}
+}
+
+func TestStreamOptions(t *testing.T) {
+	t.Run("UnsupportedMediaType", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/events", strings.NewReader("id=1"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		for _, err := range Stream[streamEvent](r) {
+			if !errors.Is(err, ErrUnsupportedMediaType) {
+				t.Errorf("Expected ErrUnsupportedMediaType, got %v", err)
+			}
+		}
+	})
+
+	t.Run("MaxBodySize", func(t *testing.T) {
+		body := strings.Repeat("{\"id\": 1}\n", 100)
+		r := httptest.NewRequest("POST", "/events", strings.NewReader(body))
+		r.ContentLength = -1
+
+		var count int
+		var lastErr error
+		for _, err := range StreamWithOptions[streamEvent](r, BindOptions{MaxBodySize: 50}) {
+			count++
+			lastErr = err
+		}
+		if !errors.Is(lastErr, ErrBodyTooLarge) {
+			t.Errorf("Expected ErrBodyTooLarge, got %v", lastErr)
+		}
+		if count > 6 {
+			t.Errorf("Expected stream to stop at the limit, got %d results", count)
+		}
+	})
+
+	t.Run("LargerThanDefaultLimit", func(t *testing.T) {
+		record := "{\"id\": 1, \"name\": \"" + strings.Repeat("x", 4096) + "\"}\n"
+		n := DefaultMaxBodySize/len(record) + 1000
+		body := []byte(strings.Repeat(record, n))
+
+		for _, encoding := range []string{"", "gzip"} {
+			payload := body
+			if encoding != "" {
+				payload = compress(t, encoding, body)
+			}
+			r := httptest.NewRequest("POST", "/events", bytes.NewReader(payload))
+			r.Header.Set("Content-Type", "application/x-ndjson")
+			r.Header.Set("Content-Encoding", encoding)
+
+			var count int
+			for _, err := range Stream[streamEvent](r) {
+				if err != nil {
+					t.Fatalf("Stream failed with error: %v", err)
+				}
+				count++
+			}
+			if count != n {
+				t.Errorf("Expected %d records, got %d", n, count)
+			}
+		}
+	})
+
+	t.Run("MaxDepth", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/events", strings.NewReader(`[{"id": 1}, {"id": 2, "x": {"y": {}}}]`))
+
+		var lastErr error
+		for _, err := range StreamWithOptions[streamEvent](r, BindOptions{MaxDepth: 2}) {
+			lastErr = err
+		}
+		if !errors.Is(lastErr, ErrBodyTooDeep) {
+			t.Errorf("Expected ErrBodyTooDeep, got %v", lastErr)
+		}
+	})
+
+	t.Run("Gzip", func(t *testing.T) {
+		body := compress(t, "gzip", []byte("{\"id\": 1}\n{\"id\": 2}\n"))
+		r := httptest.NewRequest("POST", "/events", bytes.NewReader(body))
+		r.Header.Set("Content-Encoding", "gzip")
+
+		var count int
+		for _, err := range Stream[streamEvent](r) {
+			if err != nil {
+				t.Fatalf("Stream failed with error: %v", err)
+			}
+			count++
+		}
+		if count != 2 {
+			t.Errorf("Expected 2 records, got %d", count)
+		}
+	})
+}
@@ -0,0 +1,349 @@
+package binder
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownField is matched by the error returned when
+// BindOptions.RejectUnknownFields is set and a request carries keys that no
+// field binds. Handlers will usually map it to 400 Bad Request.
+var ErrUnknownField = errors.New("unknown field")
+
+// UnknownField is a request key that no field binds
+type UnknownField struct {
+	Source string // "query", "body" or "form"
+	Key    string // nested keys are dotted, e.g. "address.zip" or "items[0].sku"
+}
+
+// UnknownFieldsError lists every unknown key in a request. It matches
+// ErrUnknownField with errors.Is.
+type UnknownFieldsError struct {
+	Fields []UnknownField
+}
+
+// Error implements the error interface
+func (e *UnknownFieldsError) Error() string {
+	keys := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		keys[i] = fmt.Sprintf("%s %q", f.Source, f.Key)
+	}
+	if len(keys) == 1 {
+		return "unknown field: " + keys[0]
+	}
+	return "unknown fields: " + strings.Join(keys, ", ")
+}
+
+// Unwrap returns ErrUnknownField
+func (e *UnknownFieldsError) Unwrap() error {
+	return ErrUnknownField
+}
+
+// fieldPlan lists the request keys a struct binds, per source, with the plan
+// for the keys of each nested struct. A nil plan is a field whose value has
+// no keys of its own, such as a string.
+type fieldPlan struct {
+	query map[string]*fieldPlan
+	body  map[string]*fieldPlan
+	open  bool // every key is accepted, as by a map or whole body field
+}
+
+// openPlan is the plan of values that accept any keys, such as maps
+var openPlan = &fieldPlan{open: true}
+
+// planOptions are the options that change the keys a struct binds: the
+// naming strategy for bind tags without keys, the tag names read and the
+// key matching, whose folded form of each key the plan stores
+type planOptions struct {
+	naming NamingStrategy
+	tags   TagNames
+	match  KeyMatching
+}
+
+// key returns the form in which a plan stores a key
+func (po planOptions) key(name string) string {
+	return po.match.fold(name)
+}
+
+// fieldPlanKey identifies a cached plan
+type fieldPlanKey struct {
+	typ reflect.Type
+	planOptions
+}
+
+// Cache of field plans. Top-level plans keep query keys apart from body
+// keys; nested plans merge them, as lookupBodyField does.
+var fieldPlans = make(map[fieldPlanKey]*fieldPlan)
+var nestedPlans = make(map[fieldPlanKey]*fieldPlan)
+var fieldPlanMutex sync.RWMutex
+
+// getFieldPlan returns the cached plan of the keys a struct type binds
+func getFieldPlan(typ reflect.Type, po planOptions) *fieldPlan {
+	key := fieldPlanKey{typ, po}
+	fieldPlanMutex.RLock()
+	plan, found := fieldPlans[key]
+	fieldPlanMutex.RUnlock()
+
+	if found {
+		return plan
+	}
+
+	// Build the plan, with nested plans, under the write lock
+	fieldPlanMutex.Lock()
+	defer fieldPlanMutex.Unlock()
+
+	// Check again in case another goroutine built it while we were waiting
+	if plan, found = fieldPlans[key]; found {
+		return plan
+	}
+
+	plan = &fieldPlan{
+		query: make(map[string]*fieldPlan),
+		body:  make(map[string]*fieldPlan),
+	}
+	info := getFieldInfo(typ)
+	if po.tags != (TagNames{}) {
+		// The plan is cached, so info for custom tags is built only once
+		info = buildFieldInfo(typ, po.tags)
+	}
+	for _, fi := range info {
+		switch fi.Source {
+		case bind:
+			refs, _ := parseBindTag(fi.TagName, fi.FieldType.Name, po.naming)
+			for _, ref := range refs {
+				switch ref.Source {
+				case query:
+					plan.query[po.key(ref.Name)] = valuePlan(fi.FieldType.Type, po)
+				case body:
+					plan.body[po.key(ref.Name)] = valuePlan(fi.FieldType.Type, po)
+				}
+			}
+		case query:
+			plan.query[po.key(tagName(fi.TagName))] = valuePlan(fi.FieldType.Type, po)
+		case body, jjson, xxml:
+			plan.addBodyField(fi.FieldType, po)
+		}
+	}
+
+	fieldPlans[key] = plan
+	return plan
+}
+
+// nestedPlan returns the plan of a struct bound from a nested object. The
+// caller must hold fieldPlanMutex for writing.
+func nestedPlan(typ reflect.Type, po planOptions) *fieldPlan {
+	key := fieldPlanKey{typ, po}
+	if plan, found := nestedPlans[key]; found {
+		return plan
+	}
+
+	// Stored before its fields are planned so recursive types terminate
+	plan := &fieldPlan{body: make(map[string]*fieldPlan)}
+	nestedPlans[key] = plan
+	for _, field := range structFields(typ, po.tags) {
+		if field.IsExported() {
+			plan.addBodyField(field, po)
+		}
+	}
+	return plan
+}
+
+// addBodyField adds the key a field binds from a body object, choosing the
+// tag lookupBodyField would
+func (p *fieldPlan) addBodyField(field reflect.StructField, po planOptions) {
+	bodyTag := field.Tag.Get(body)
+	switch {
+	case field.Tag.Get(bind) != "":
+		refs, _ := parseBindTag(field.Tag.Get(bind), field.Name, po.naming)
+		for _, ref := range refs {
+			if ref.Source == body || ref.Source == query {
+				p.body[po.key(ref.Name)] = valuePlan(field.Type, po)
+			}
+		}
+	case isRawBodyTag(bodyTag):
+		// Raw bytes bind no keys, so they leave the plan as it is
+	case isWholeBodyTag(bodyTag):
+		p.open = true
+	case tagName(bodyTag) != "":
+		p.body[po.key(tagName(bodyTag))] = valuePlan(field.Type, po)
+	case tagName(field.Tag.Get(jjson)) != "":
+		p.body[po.key(tagName(field.Tag.Get(jjson)))] = valuePlan(field.Type, po)
+	case field.Tag.Get(xxml) != "":
+		p.addXMLField(field, po)
+	case tagName(field.Tag.Get(query)) != "":
+		p.body[po.key(tagName(field.Tag.Get(query)))] = valuePlan(field.Type, po)
+	}
+}
+
+// addXMLField adds the key path of an xml tag, as lookupXMLField reads it
+func (p *fieldPlan) addXMLField(field reflect.StructField, po planOptions) {
+	name, opts, _ := strings.Cut(field.Tag.Get(xxml), ",")
+	if name == "-" {
+		return
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		switch opt {
+		case "chardata":
+			p.body[po.key(xmlText)] = nil
+			return
+		case "innerxml", "any":
+			p.open = true
+			return
+		case "comment":
+			return
+		}
+	}
+	if name == "" {
+		name = field.Name
+	}
+
+	parts := strings.Split(po.key(name), ">")
+	current := p
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current.body[part]
+		if !ok || next == nil {
+			next = &fieldPlan{body: make(map[string]*fieldPlan)}
+			current.body[part] = next
+		}
+		current = next
+	}
+	current.body[parts[len(parts)-1]] = valuePlan(field.Type, po)
+}
+
+// valuePlan returns the plan for the keys of a field's value: a nested plan
+// for structs and elements of struct lists, an open plan for maps and
+// interfaces, and nil for values without keys
+func valuePlan(t reflect.Type, po planOptions) *fieldPlan {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Map:
+		return openPlan
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return openPlan
+		}
+	case reflect.Struct:
+		if t == timeType || t == presenceType {
+			return nil
+		}
+		if _, ok := asOptional(reflect.New(t).Elem()); ok {
+			return valuePlan(t.Field(0).Type, po)
+		}
+		textUnmarshaler := reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+		if t.Implements(textUnmarshaler) || reflect.PointerTo(t).Implements(textUnmarshaler) {
+			return nil
+		}
+		return nestedPlan(t, po)
+	}
+	return nil
+}
+
+// keyCheck collects the unknown keys of one request source
+type keyCheck struct {
+	source   string
+	expanded bool // keys in bracket or dot notation were expanded into nested values
+	match    KeyMatching
+	unknown  []UnknownField
+}
+
+// checkKeys records the keys of data that are not among the planned keys.
+// Keys of expanded form and query data that are written in bracket or dot
+// notation are checked through the nested value built for their root.
+func (c *keyCheck) checkKeys(data map[string]interface{}, keys map[string]*fieldPlan, prefix string) {
+	names := make([]string, 0, len(data))
+	for k := range data {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		child, known := planKey(keys, k, c.match)
+		if !known && c.expanded {
+			if segments, ok := splitKey(k); ok {
+				if _, hasRoot := data[segments[0]]; hasRoot {
+					continue
+				}
+			} else if flatKeysKnown(data, k, keys, c.match) {
+				// A root added for flat keys that fields bind directly
+				continue
+			}
+		}
+		if !known {
+			c.unknown = append(c.unknown, UnknownField{Source: c.source, Key: prefix + k})
+			continue
+		}
+		c.checkValue(child, data[k], prefix+k)
+	}
+}
+
+// checkValue checks the keys of a nested object or list of objects
+func (c *keyCheck) checkValue(p *fieldPlan, value interface{}, key string) {
+	if p == nil || p.open {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c.checkKeys(v, p.body, key+".")
+	case []interface{}:
+		for i, elem := range v {
+			c.checkValue(p, elem, key+"["+strconv.Itoa(i)+"]")
+		}
+	}
+}
+
+// planKey finds the planned key that a request key matches. Plans store
+// keys folded, so this is a single lookup.
+func planKey(keys map[string]*fieldPlan, key string, match KeyMatching) (*fieldPlan, bool) {
+	child, ok := keys[match.fold(key)]
+	return child, ok
+}
+
+// flatKeysKnown reports whether every bracket or dot notation key under root
+// is bound directly by a field, so the nested value expandKeys added for
+// root is not an unknown key
+func flatKeysKnown(data map[string]interface{}, root string, keys map[string]*fieldPlan, match KeyMatching) bool {
+	found := false
+	for k := range data {
+		if segments, ok := splitKey(k); ok && segments[0] == root {
+			if _, known := planKey(keys, k, match); !known {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// rejectUnknownFields reports the query and body keys of a request that no
+// field of typ binds. Non-object bodies, such as JSON arrays, have no keys
+// to check.
+func rejectUnknownFields(r *http.Request, typ reflect.Type, bodyData interface{}, opts *BindOptions) error {
+	plan := getFieldPlan(typ, planOptions{opts.Naming, opts.Tags, opts.KeyMatching})
+
+	queryCheck := keyCheck{source: "query", expanded: true, match: opts.KeyMatching}
+	if values := r.URL.Query(); len(values) > 0 {
+		queryCheck.checkKeys(expandKeys(valuesMap(values)), plan.query, "")
+	}
+	bodyCheck := keyCheck{source: "body", match: opts.KeyMatching}
+	if parseContentType(r.Header.Get("Content-Type")) == "application/x-www-form-urlencoded" {
+		bodyCheck.source, bodyCheck.expanded = "form", true
+	}
+	if fields, ok := bodyData.(map[string]interface{}); ok && !plan.open {
+		bodyCheck.checkKeys(fields, plan.body, "")
+	}
+
+	if unknown := append(queryCheck.unknown, bodyCheck.unknown...); len(unknown) > 0 {
+		return &UnknownFieldsError{Fields: unknown}
+	}
+	return nil
+}
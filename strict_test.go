@@ -0,0 +1,223 @@
+package binder
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type strictAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type strictItem struct {
+	SKU string `json:"sku"`
+	Qty int    `json:"qty"`
+}
+
+type strictOrder struct {
+	ID       int               `path:"id"`
+	Limit    int               `query:"limit"`
+	Name     string            `json:"name"`
+	Address  strictAddress     `json:"address"`
+	Items    []strictItem      `json:"items"`
+	Metadata map[string]string `json:"metadata"`
+	Token    string            `cookie:"token"`
+}
+
+func TestBindRejectUnknownFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		contentType string
+		body        string
+		unknown     []UnknownField
+	}{
+		{
+			name:        "known keys",
+			url:         "/orders?limit=10",
+			contentType: "application/json",
+			body:        `{"name":"a","address":{"street":"x"},"items":[{"sku":"s"}],"metadata":{"any":"key"}}`,
+		},
+		{
+			name:        "unknown query and body keys",
+			url:         "/orders?limit=10&limt=5",
+			contentType: "application/json",
+			body:        `{"name":"a","nmae":"b"}`,
+			unknown:     []UnknownField{{"query", "limt"}, {"body", "nmae"}},
+		},
+		{
+			name:        "nested body keys",
+			url:         "/orders",
+			contentType: "application/json",
+			body:        `{"address":{"street":"x","zip":"1"},"items":[{"sku":"s"},{"sku":"t","price":2}]}`,
+			unknown:     []UnknownField{{"body", "address.zip"}, {"body", "items[1].price"}},
+		},
+		{
+			name:        "form keys",
+			url:         "/orders",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "name=a&address[street]=x&address[zip]=1&items[0][sku]=s&extra=1",
+			unknown:     []UnknownField{{"form", "address.zip"}, {"form", "extra"}},
+		},
+		{
+			name:        "unknown nested root",
+			url:         "/orders?filter[status]=open",
+			contentType: "application/json",
+			body:        `{}`,
+			unknown:     []UnknownField{{"query", "filter"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+
+			var order strictOrder
+			err := BindWithOptions(req, &order, BindOptions{RejectUnknownFields: true})
+			if tt.unknown == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var unknownErr *UnknownFieldsError
+			if !errors.As(err, &unknownErr) {
+				t.Fatalf("Expected UnknownFieldsError, got %v", err)
+			}
+			if !errors.Is(err, ErrUnknownField) {
+				t.Errorf("Expected error to match ErrUnknownField, got %v", err)
+			}
+			if !reflect.DeepEqual(unknownErr.Fields, tt.unknown) {
+				t.Errorf("Expected unknown fields to be %v, got %v", tt.unknown, unknownErr.Fields)
+			}
+		})
+	}
+}
+
+func TestBindUnknownFieldsIgnoredByDefault(t *testing.T) {
+	req := httptest.NewRequest("POST", "/orders?limt=5", strings.NewReader(`{"nmae":"b"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var order strictOrder
+	if err := Bind(req, &order); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestBindRejectUnknownFieldsWholeBody(t *testing.T) {
+	type request struct {
+		Limit int                    `query:"limit"`
+		Body  map[string]interface{} `body:"-"`
+	}
+
+	req := httptest.NewRequest("POST", "/?limit=1&page=2", strings.NewReader(`{"anything":1}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var r request
+	err := BindWithOptions(req, &r, BindOptions{RejectUnknownFields: true})
+	if err == nil || err.Error() != `unknown field: query "page"` {
+		t.Errorf("Expected only the query key to be unknown, got %v", err)
+	}
+}
+
+func TestBindRejectUnknownFieldsRawBody(t *testing.T) {
+	type request struct {
+		Raw  []byte `body:",raw"`
+		Name string `body:"name"`
+	}
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"a","nmae":"b"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var r request
+	err := BindWithOptions(req, &r, BindOptions{RejectUnknownFields: true})
+	if err == nil || err.Error() != `unknown field: body "nmae"` {
+		t.Errorf("Expected nmae to be unknown, got %v", err)
+	}
+}
+
+func TestBindRejectUnknownFieldsXML(t *testing.T) {
+	type request struct {
+		ID   string `xml:"id,attr"`
+		City string `xml:"address>city"`
+	}
+
+	body := `<user id="1" role="admin"><address><city>X</city><zip>1</zip></address></user>`
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/xml")
+
+	var r request
+	err := BindWithOptions(req, &r, BindOptions{RejectUnknownFields: true})
+	want := `unknown fields: body "address.zip", body "role"`
+	if err == nil || err.Error() != want {
+		t.Errorf("Expected error to be %q, got %v", want, err)
+	}
+}
+
+func TestGetFieldPlanRecursive(t *testing.T) {
+	type node struct {
+		Name     string  `json:"name"`
+		Children []*node `json:"children"`
+	}
+
+	plan := getFieldPlan(reflect.TypeOf(struct {
+		Root node `json:"root"`
+	}{}), planOptions{})
+	child := plan.body["root"].body["children"]
+	if child != plan.body["root"] {
+		t.Errorf("Expected recursive plan to reuse the nested plan")
+	}
+}
+
+func TestBindRejectUnknownFieldsKeyMatching(t *testing.T) {
+	type request struct {
+		PageSize int    `query:"page_size"`
+		Email    string `json:"email"`
+	}
+
+	req := httptest.NewRequest("POST", "/?pageSize=1&Page-Size=2", strings.NewReader(`{"EMAIL":"a","e_mail":"b","mail":"c"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var r request
+	err := BindWithOptions(req, &r, BindOptions{RejectUnknownFields: true, KeyMatching: MatchNormalized})
+	if err == nil || err.Error() != `unknown field: body "mail"` {
+		t.Errorf("Expected mail to be unknown, got %v", err)
+	}
+}
+
+func TestBindRejectUnknownFieldsConcurrent(t *testing.T) {
+	type item struct {
+		SKU string `json:"sku"`
+	}
+	type request struct {
+		Items []item `json:"items"`
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(`{"items":[{"sku":"a","qty":1}]}`))
+			req.Header.Set("Content-Type", "application/json")
+			var r request
+			errs <- BindWithOptions(req, &r, BindOptions{RejectUnknownFields: true})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err == nil || err.Error() != `unknown field: body "items[0].qty"` {
+			t.Errorf("Expected items[0].qty to be unknown, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package binder
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TagNames renames the struct tags binder reads, so that it can share types
+// with libraries whose tag conventions collide with its own. An empty name
+// keeps the default and "-" disables the source.
+//
+// Example:
+//
+//	// Read path parameters from uri tags and leave json tags to encoding/json
+//	tags := binder.TagNames{Path: "uri", JSON: "-"}
+type TagNames struct {
+	Path   string
+	Query  string
+	Body   string
+	JSON   string
+	XML    string
+	Cookie string
+	Bind   string
+}
+
+// key returns the tag key read for a source, or "" if it is disabled
+func (t TagNames) key(source string) string {
+	var name string
+	switch source {
+	case path:
+		name = t.Path
+	case query:
+		name = t.Query
+	case body:
+		name = t.Body
+	case jjson:
+		name = t.JSON
+	case xxml:
+		name = t.XML
+	case cookie:
+		name = t.Cookie
+	case bind:
+		name = t.Bind
+	}
+	switch name {
+	case "":
+		return source
+	case "-":
+		return ""
+	}
+	return name
+}
+
+// rewrite returns a struct tag with the configured tag keys renamed to the
+// default ones the rest of binder reads. Disabled sources, and tags that
+// merely share a default name, are dropped. Other tags, such as layout, are
+// kept as they are.
+func (t TagNames) rewrite(tag reflect.StructTag) reflect.StructTag {
+	sources := []string{path, query, body, jjson, xxml, cookie, bind}
+	configured := make(map[string]bool, len(sources))
+	var b strings.Builder
+	for _, source := range sources {
+		key := t.key(source)
+		if key == "" {
+			continue
+		}
+		configured[key] = true
+		if value, ok := tag.Lookup(key); ok {
+			b.WriteString(source + ":" + strconv.Quote(value) + " ")
+		}
+	}
+
+	for _, key := range tagKeys(tag) {
+		isSource := false
+		for _, source := range sources {
+			isSource = isSource || key == source
+		}
+		if !isSource && !configured[key] {
+			b.WriteString(key + ":" + strconv.Quote(tag.Get(key)) + " ")
+		}
+	}
+	return reflect.StructTag(strings.TrimSpace(b.String()))
+}
+
+// tagKeys returns the keys of a struct tag in the conventional
+// key:"value" format, in order
+func tagKeys(tag reflect.StructTag) []string {
+	var keys []string
+	s := string(tag)
+	for {
+		s = strings.TrimLeft(s, " ")
+		colon := strings.Index(s, `:"`)
+		if colon <= 0 || strings.ContainsAny(s[:colon], " \"") {
+			return keys
+		}
+		keys = append(keys, s[:colon])
+
+		// Skip the quoted value, honouring escaped quotes
+		i := colon + 2
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			return keys
+		}
+		s = s[i+1:]
+	}
+}
+
+// Binder binds requests with a fixed set of options, such as custom tag
+// names, so that handlers need not pass them on every call. A Binder is
+// safe for concurrent use.
+//
+// Example:
+//
+//	var bind = binder.New(binder.BindOptions{
+//	    Tags:   binder.TagNames{Path: "uri", JSON: "-"},
+//	    Naming: binder.NameSnakeCase,
+//	})
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    var req GetUserRequest
+//	    if err := bind.Bind(r, &req); err != nil {
+//	        // Handle binding error
+//	    }
+//	}
+type Binder struct {
+	opts BindOptions
+}
+
+// New returns a Binder that applies opts to every call
+func New(opts BindOptions) *Binder {
+	return &Binder{opts: opts}
+}
+
+// Options returns the Binder's options, for use with StreamWithOptions
+func (b *Binder) Options() BindOptions {
+	return b.opts
+}
+
+// options returns the options for one call. They are shared between calls
+// unless key matching needs the per-call cache in keyIndexes, which is
+// kept in a copy.
+func (b *Binder) options() *BindOptions {
+	if b.opts.KeyMatching == MatchExact {
+		return &b.opts
+	}
+	opts := b.opts
+	return &opts
+}
+
+// Bind behaves like the package-level Bind with the Binder's options
+func (b *Binder) Bind(r *http.Request, i interface{}) error {
+	return bindWithOptions(r, i, b.options())
+}
+
+// ApplyMergePatch behaves like the package-level ApplyMergePatch with the
+// Binder's options
+func (b *Binder) ApplyMergePatch(r *http.Request, i interface{}) error {
+	return applyMergePatch(r, i, b.options())
+}
+
+// ApplyJSONPatch behaves like the package-level ApplyJSONPatch with the
+// Binder's options
+func (b *Binder) ApplyJSONPatch(r *http.Request, i interface{}) error {
+	return applyJSONPatch(r, i, b.options())
+}
@@ -0,0 +1,176 @@
+package binder
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTagKeys(t *testing.T) {
+	tag := reflect.StructTag(`uri:"id" json:"a\"b,omitempty"  layout:"2006-01-02" bad`)
+	want := []string{"uri", "json", "layout"}
+	if got := tagKeys(tag); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestTagNamesRewrite(t *testing.T) {
+	tests := []struct {
+		name string
+		tags TagNames
+		tag  reflect.StructTag
+		want reflect.StructTag
+	}{
+		{
+			name: "renamed source",
+			tags: TagNames{Path: "uri"},
+			tag:  `uri:"id" path:"ignored"`,
+			want: `path:"id"`,
+		},
+		{
+			name: "disabled source",
+			tags: TagNames{JSON: "-"},
+			tag:  `json:"name" body:"name,omitempty"`,
+			want: `body:"name,omitempty"`,
+		},
+		{
+			name: "other tags kept",
+			tags: TagNames{Query: "form"},
+			tag:  `form:"since" layout:"2006-01-02" validate:"required"`,
+			want: `query:"since" layout:"2006-01-02" validate:"required"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tags.rewrite(tt.tag); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+type taggedUser struct {
+	ID       int    `uri:"id"`
+	Name     string `in:"name"`
+	Nickname string `json:"nickname"`
+	Legacy   string `path:"id"`
+}
+
+func TestBinderCustomTags(t *testing.T) {
+	b := New(BindOptions{Tags: TagNames{Path: "uri", Body: "in", JSON: "-"}})
+
+	req := httptest.NewRequest("POST", "/users/42", strings.NewReader(`{"name":"Alice","nickname":"al"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "42")
+
+	var u taggedUser
+	if err := b.Bind(req, &u); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := taggedUser{ID: 42, Name: "Alice"}
+	if u != want {
+		t.Errorf("Expected %+v, got %+v", want, u)
+	}
+
+	// The package-level functions keep the default tag names
+	var d taggedUser
+	req = httptest.NewRequest("POST", "/users/42", strings.NewReader(`{"name":"Alice","nickname":"al"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.SetPathValue("id", "42")
+	if err := Bind(req, &d); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want = taggedUser{Nickname: "al", Legacy: "42"}
+	if d != want {
+		t.Errorf("Expected %+v, got %+v", want, d)
+	}
+}
+
+func TestBinderCustomTagsStrict(t *testing.T) {
+	b := New(BindOptions{Tags: TagNames{Body: "in", JSON: "-"}, RejectUnknownFields: true})
+
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Alice","nickname":"al"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var u taggedUser
+	err := b.Bind(req, &u)
+	if !errors.Is(err, ErrUnknownField) || err.Error() != `unknown field: body "nickname"` {
+		t.Errorf("Expected nickname to be unknown, got %v", err)
+	}
+}
+
+func TestBinderCustomTagsPatch(t *testing.T) {
+	b := New(BindOptions{Tags: TagNames{Body: "in", JSON: "-"}})
+	u := taggedUser{Name: "Alice", Nickname: "al"}
+
+	req := httptest.NewRequest("PATCH", "/", strings.NewReader(`{"name":"Bob","nickname":"bobby"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	if err := b.ApplyMergePatch(req, &u); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if u.Name != "Bob" || u.Nickname != "al" {
+		t.Errorf("Expected only Name to change, got %+v", u)
+	}
+
+	req = httptest.NewRequest("PATCH", "/", strings.NewReader(`[{"op":"replace","path":"/name","value":"Carol"}]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	if err := b.ApplyJSONPatch(req, &u); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if u.Name != "Carol" {
+		t.Errorf("Expected Name to be Carol, got %q", u.Name)
+	}
+}
+
+func TestBinderDefaultSourceWithDisabledJSON(t *testing.T) {
+	type request struct {
+		_        struct{} `bind:"query"`
+		PageSize int      `json:"page_size"`
+	}
+
+	b := New(BindOptions{Tags: TagNames{JSON: "-"}, Naming: NameSnakeCase})
+	req := httptest.NewRequest("GET", "/?page_size=25", nil)
+
+	var r request
+	if err := b.Bind(req, &r); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if r.PageSize != 25 {
+		t.Errorf("Expected PageSize to be 25, got %d", r.PageSize)
+	}
+}
+
+func TestBinderConcurrentKeyMatching(t *testing.T) {
+	type request struct {
+		PageSize int `json:"page_size"`
+	}
+
+	b := New(BindOptions{KeyMatching: MatchNormalized})
+	var wg sync.WaitGroup
+	for n := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(`{"pageSize":`+strconv.Itoa(n)+`}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			var r request
+			if err := b.Bind(req, &r); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if r.PageSize != n {
+				t.Errorf("Expected PageSize to be %d, got %d", n, r.PageSize)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if b.opts.keyIndexes != nil {
+		t.Errorf("Expected the Binder's options to stay unwritten")
+	}
+}
@@ -0,0 +1,189 @@
+package binder
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// layoutTag is the tag used to specify how a time.Time field is parsed, e.g.
+// `query:"since" layout:"2006-01-02"`. Besides any time.Parse layout it
+// accepts the special values below for Unix timestamps.
+const layoutTag = "layout"
+
+// Unix timestamp layouts accepted by the layout tag
+const (
+	layoutUnix      = "unix"
+	layoutUnixMilli = "unixmilli"
+	layoutUnixMicro = "unixmicro"
+	layoutUnixNano  = "unixnano"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// trySetTime handles time.Time and time.Duration fields.
+// Returns (handled, error) where handled indicates if the field was a time type
+func trySetTime(field reflect.Value, value interface{}, tag reflect.StructTag, opts *BindOptions) (bool, error) {
+	switch field.Type() {
+	case timeType:
+		t, err := toTime(value, tag.Get(layoutTag), opts.location())
+		if err != nil {
+			return true, err
+		}
+		field.Set(reflect.ValueOf(t))
+		return true, nil
+
+	case durationType:
+		d, err := toDuration(value, opts.durationUnit())
+		if err != nil {
+			return true, err
+		}
+		field.SetInt(int64(d))
+		return true, nil
+	}
+	return false, nil
+}
+
+// toTime converts a string or number to a time.Time using the given layout.
+// An empty layout means RFC 3339.
+func toTime(value interface{}, layout string, loc *time.Location) (time.Time, error) {
+	if isUnixLayout(layout) {
+		return toUnixTime(value, layout, loc)
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("cannot convert %T to time.Time without a unix layout", value)
+	}
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return time.ParseInLocation(layout, str, loc)
+}
+
+// isUnixLayout reports whether layout names one of the Unix timestamp variants
+func isUnixLayout(layout string) bool {
+	switch layout {
+	case layoutUnix, layoutUnixMilli, layoutUnixMicro, layoutUnixNano:
+		return true
+	}
+	return false
+}
+
+// toUnixTime converts a Unix timestamp in the precision named by layout
+func toUnixTime(value interface{}, layout string, loc *time.Location) (time.Time, error) {
+	var n float64
+	switch v := value.(type) {
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s timestamp %q", layout, v)
+		}
+		n = f
+	case float64:
+		n = v
+	case float32:
+		n = float64(v)
+	case int:
+		n = float64(v)
+	case int64:
+		n = float64(v)
+	default:
+		return time.Time{}, fmt.Errorf("cannot convert %T to time.Time", value)
+	}
+
+	lo, hi := unixRange(layout)
+	if math.IsNaN(n) || n < float64(lo) || n > float64(hi) {
+		return time.Time{}, fmt.Errorf("%s timestamp %v out of range [%d, %d]", layout, n, lo, hi)
+	}
+
+	var t time.Time
+	switch layout {
+	case layoutUnix:
+		sec, frac := math.Modf(n)
+		t = time.Unix(int64(sec), int64(frac*1e9))
+	case layoutUnixMilli:
+		t = time.UnixMilli(int64(n))
+	case layoutUnixMicro:
+		t = time.UnixMicro(int64(n))
+	case layoutUnixNano:
+		t = time.Unix(0, int64(n))
+	}
+	return t.In(loc), nil
+}
+
+// unixRange returns the timestamps accepted in the precision named by
+// layout: years 1 to 9999, as RFC 3339 allows, or for nanoseconds the range
+// of an int64
+func unixRange(layout string) (lo, hi int64) {
+	const minSec, maxSec = -62135596800, 253402300799 // 0001-01-01 to 9999-12-31T23:59:59
+	switch layout {
+	case layoutUnixMilli:
+		return minSec * 1e3, maxSec*1e3 + 999
+	case layoutUnixMicro:
+		return minSec * 1e6, maxSec*1e6 + 999999
+	case layoutUnixNano:
+		// float64(math.MaxInt64) rounds up to 2^63, so the top is kept clear
+		return math.MinInt64, math.MaxInt64 - 1023
+	}
+	return minSec, maxSec
+}
+
+// toDuration converts a duration string such as "1h30m" or a number in the
+// given unit to a time.Duration
+func toDuration(value interface{}, unit time.Duration) (time.Duration, error) {
+	switch v := value.(type) {
+	case string:
+		d, err := time.ParseDuration(v)
+		if err == nil {
+			return d, nil
+		}
+		// Bare numbers are interpreted in the configured unit
+		f, ferr := strconv.ParseFloat(v, 64)
+		if ferr != nil {
+			return 0, err
+		}
+		return floatDuration(f, unit)
+	case float64:
+		return floatDuration(v, unit)
+	case float32:
+		return floatDuration(float64(v), unit)
+	case int:
+		return intDuration(int64(v), unit)
+	case int64:
+		return intDuration(v, unit)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to time.Duration", value)
+	}
+}
+
+// floatDuration converts n units to a time.Duration, rejecting values that
+// do not fit
+func floatDuration(n float64, unit time.Duration) (time.Duration, error) {
+	d := n * float64(unit)
+	// float64(math.MaxInt64) rounds up to 2^63, which does not fit
+	if math.IsNaN(d) || d < math.MinInt64 || d >= math.MaxInt64 {
+		return 0, durationRangeError(n, unit)
+	}
+	return time.Duration(d), nil
+}
+
+// intDuration converts n units to a time.Duration, rejecting values that do
+// not fit
+func intDuration(n int64, unit time.Duration) (time.Duration, error) {
+	if n > int64(math.MaxInt64/unit) || n < int64(math.MinInt64/unit) {
+		return 0, durationRangeError(n, unit)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// durationRangeError reports a duration outside the range of time.Duration
+func durationRangeError(n interface{}, unit time.Duration) error {
+	return fmt.Errorf("duration %v in units of %v out of range [%v, %v]",
+		n, unit, time.Duration(math.MinInt64), time.Duration(math.MaxInt64))
+}
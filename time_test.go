@@ -0,0 +1,213 @@
+package binder
+
+import (
+	"bytes"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBindDuration(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?timeout=1m30s&delay=250", nil)
+
+	type params struct {
+		Timeout time.Duration `query:"timeout"`
+		Delay   time.Duration `query:"delay"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+
+	if p.Timeout != 90*time.Second {
+		t.Errorf("Expected Timeout to be 1m30s, got %s", p.Timeout)
+	}
+	if p.Delay != 250 {
+		t.Errorf("Expected Delay to be 250ns, got %s", p.Delay)
+	}
+}
+
+func TestBindDurationUnit(t *testing.T) {
+	r := httptest.NewRequest("POST", "/test?delay=250", bytes.NewBufferString(`{"timeout": 30, "grace": "2s"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	type params struct {
+		Delay   time.Duration `query:"delay"`
+		Timeout time.Duration `body:"timeout"`
+		Grace   time.Duration `body:"grace"`
+	}
+
+	var p params
+	if err := BindWithOptions(r, &p, BindOptions{DurationUnit: time.Millisecond}); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+
+	if p.Delay != 250*time.Millisecond {
+		t.Errorf("Expected Delay to be 250ms, got %s", p.Delay)
+	}
+	if p.Timeout != 30*time.Millisecond {
+		t.Errorf("Expected Timeout to be 30ms, got %s", p.Timeout)
+	}
+	if p.Grace != 2*time.Second {
+		t.Errorf("Expected Grace to be 2s, got %s", p.Grace)
+	}
+}
+
+func TestBindInvalidDuration(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?timeout=soon", nil)
+
+	type params struct {
+		Timeout time.Duration `query:"timeout"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err == nil {
+		t.Errorf("Binding should fail with invalid duration")
+	}
+}
+
+func TestBindDurationOutOfRange(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		unit time.Duration
+	}{
+		{"float", "/test?d=1e30", 0},
+		{"negative float", "/test?d=-1e30", 0},
+		{"int in unit", "/test?d=9223372036854775807", time.Second},
+	}
+
+	type params struct {
+		D time.Duration `query:"d"`
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.url, nil)
+
+			var p params
+			err := BindWithOptions(r, &p, BindOptions{DurationUnit: tt.unit})
+			if err == nil || !strings.Contains(err.Error(), "out of range") {
+				t.Errorf("Expected an out of range error, got %v (bound %s)", err, p.D)
+			}
+		})
+	}
+}
+
+func TestToDurationInt(t *testing.T) {
+	if _, err := toDuration(int64(math.MaxInt64/int64(time.Second)+1), time.Second); err == nil {
+		t.Errorf("Expected an error for a duration past the int64 range")
+	}
+	d, err := toDuration(int64(math.MaxInt64/int64(time.Second)), time.Second)
+	if err != nil || d <= 0 {
+		t.Errorf("Expected the largest whole second duration to bind, got %s, %v", d, err)
+	}
+}
+
+func TestBindTime(t *testing.T) {
+	r := httptest.NewRequest("GET", "/test?at=2024-03-01T10:00:00%2B02:00&day=2024-03-01", nil)
+
+	type params struct {
+		At  time.Time  `query:"at"`
+		Day *time.Time `query:"day" layout:"2006-01-02"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+
+	if !p.At.Equal(time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected At to be 2024-03-01T08:00:00Z, got %s", p.At)
+	}
+	if p.Day == nil || !p.Day.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected Day to be 2024-03-01, got %v", p.Day)
+	}
+}
+
+func TestBindTimeLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+5", 5*60*60)
+	r := httptest.NewRequest("GET", "/test?day=2024-03-01", nil)
+
+	type params struct {
+		Day time.Time `query:"day" layout:"2006-01-02"`
+	}
+
+	var p params
+	if err := BindWithOptions(r, &p, BindOptions{TimeLocation: loc}); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+
+	if !p.Day.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, loc)) {
+		t.Errorf("Expected Day to be midnight in UTC+5, got %s", p.Day)
+	}
+}
+
+func TestBindUnixTime(t *testing.T) {
+	r := httptest.NewRequest("POST", "/test?since=1700000000", bytes.NewBufferString(`{"until": 1700000000123, "stamps": [1700000000, 1700000001]}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	type params struct {
+		Since  time.Time   `query:"since" layout:"unix"`
+		Until  time.Time   `body:"until" layout:"unixmilli"`
+		Stamps []time.Time `body:"stamps" layout:"unix"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+
+	if !p.Since.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected Since to be 1700000000, got %s", p.Since)
+	}
+	if !p.Until.Equal(time.UnixMilli(1700000000123)) {
+		t.Errorf("Expected Until to be 1700000000123ms, got %s", p.Until)
+	}
+	if len(p.Stamps) != 2 || !p.Stamps[1].Equal(time.Unix(1700000001, 0)) {
+		t.Errorf("Expected two stamps, got %v", p.Stamps)
+	}
+}
+
+func TestBindUnixTimeOutOfRange(t *testing.T) {
+	tests := []struct {
+		layout string
+		value  string
+	}{
+		{"unix", "1e300"},
+		{"unix", "-1e300"},
+		{"unix", "253402300800"},
+		{"unixmilli", "1e20"},
+		{"unixnano", "9223372036854775808"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout+" "+tt.value, func(t *testing.T) {
+			if _, err := toUnixTime(tt.value, tt.layout, time.UTC); err == nil || !strings.Contains(err.Error(), "out of range") {
+				t.Errorf("Expected an out of range error, got %v", err)
+			}
+		})
+	}
+
+	at, err := toUnixTime("253402300799", layoutUnix, time.UTC)
+	if err != nil || at.Year() != 9999 {
+		t.Errorf("Expected the last second of year 9999 to bind, got %v, %v", at, err)
+	}
+}
+
+func TestBindTimeNumberWithoutLayout(t *testing.T) {
+	r := httptest.NewRequest("POST", "/test", bytes.NewBufferString(`{"at": 1700000000}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	type params struct {
+		At time.Time `body:"at"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err == nil {
+		t.Errorf("Binding should fail for a numeric time without a unix layout")
+	}
+}
@@ -0,0 +1,169 @@
+package binder
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// xmlText is the key under which the character data of an XML element with
+// attributes or child elements is stored. Fields tagged `xml:",chardata"`
+// bind from it.
+const xmlText = "#text"
+
+// xmlDecoder is the Decoder for XML media types
+type xmlDecoder struct{}
+
+// Decode decodes an XML document, honouring the encoding its prolog declares
+func (xmlDecoder) Decode(r io.Reader) (map[string]interface{}, error) {
+	return decodeXML(r, xmlCharsetReader)
+}
+
+// decodeCharset decodes an XML document sent with a charset parameter.
+// As RFC 7303 requires, the charset parameter overrides the prolog.
+func (d xmlDecoder) decodeCharset(r io.Reader, label string) (map[string]interface{}, error) {
+	if label == "" {
+		return d.Decode(r)
+	}
+
+	cs, _ := lookupCharset(label)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = cs.toUTF8(data); err != nil {
+		return nil, err
+	}
+	return decodeXML(bytes.NewReader(data), func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	})
+}
+
+// decodeXML decodes an XML document into the same map shape produced for
+// JSON bodies. The root element becomes the top-level map: its attributes
+// and child elements are keyed by local name, repeated children become
+// slices and elements holding only text become strings.
+func decodeXML(r io.Reader, charsetReader func(string, io.Reader) (io.Reader, error)) (map[string]interface{}, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("no root element")
+			}
+			return nil, err
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			root, err := decodeXMLElement(dec, start)
+			if err != nil {
+				return nil, err
+			}
+			if m, ok := root.(map[string]interface{}); ok {
+				return m, nil
+			}
+			return map[string]interface{}{xmlText: root}, nil
+		}
+	}
+}
+
+// decodeXMLElement decodes the element opened by start, returning either a
+// string for a text-only element or a map of its attributes and children
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	var m map[string]interface{}
+	for _, attr := range start.Attr {
+		// Namespace declarations are markup rather than data
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		if m == nil {
+			m = make(map[string]interface{}, len(start.Attr))
+		}
+		m[attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			if m == nil {
+				m = make(map[string]interface{})
+			}
+			addXMLChild(m, t.Name.Local, child)
+
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.EndElement:
+			if m == nil {
+				return text.String(), nil
+			}
+			// Whitespace between child elements is formatting, not content
+			if strings.TrimSpace(text.String()) != "" {
+				m[xmlText] = text.String()
+			}
+			return m, nil
+		}
+	}
+}
+
+// addXMLChild stores a child element, collecting repeated names into a slice
+func addXMLChild(m map[string]interface{}, name string, child interface{}) {
+	existing, ok := m[name]
+	if !ok {
+		m[name] = child
+		return
+	}
+	if list, ok := existing.([]interface{}); ok {
+		m[name] = append(list, child)
+		return
+	}
+	m[name] = []interface{}{existing, child}
+}
+
+// lookupXMLField resolves an xml struct tag against decoded XML data.
+// It follows encoding/xml conventions: an empty name means the field name,
+// "a>b" descends through nested elements and ",chardata" selects the
+// element text.
+func lookupXMLField(data map[string]interface{}, tag, fieldName string, opts *BindOptions) (interface{}, bool) {
+	name, flags, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return nil, false
+	}
+	for _, opt := range strings.Split(flags, ",") {
+		switch opt {
+		case "chardata":
+			v, ok := data[xmlText]
+			return v, ok
+		case "innerxml", "comment", "any":
+			return nil, false
+		}
+	}
+	if name == "" {
+		name = fieldName
+	}
+
+	var current interface{} = data
+	for _, part := range strings.Split(name, ">") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = lookupKey(m, part, opts); !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
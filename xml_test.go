@@ -0,0 +1,150 @@
+package binder
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBindXMLBody(t *testing.T) {
+	type address struct {
+		Street string `xml:"street"`
+		City   string `xml:"city"`
+	}
+
+	type item struct {
+		SKU string `xml:"sku,attr"`
+		Qty int    `xml:",chardata"`
+	}
+
+	type params struct {
+		ID      int      `xml:"id,attr"`
+		Email   string   `body:"email"`
+		Active  bool     `xml:"active"`
+		Address address  `xml:"address"`
+		City    string   `xml:"address>city"`
+		Tags    []string `xml:"tags>tag"`
+		Items   []item   `xml:"item"`
+	}
+
+	payload := `<?xml version="1.0" encoding="UTF-8"?>
+<order id="42">
+  <email>info@example.io</email>
+  <active>true</active>
+  <address>
+    <street>1 Main St</street>
+    <city>Springfield</city>
+  </address>
+  <tags><tag>new</tag><tag>priority</tag></tags>
+  <item sku="A-1">2</item>
+  <item sku="B-2">5</item>
+</order>`
+
+	for _, ct := range []string{"application/xml", "text/xml; charset=utf-8"} {
+		t.Run(ct, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+			r.Header.Set("Content-Type", ct)
+
+			var p params
+			if err := Bind(r, &p); err != nil {
+				t.Fatalf("Binding failed with error: %v", err)
+			}
+
+			expected := params{
+				ID:      42,
+				Email:   "info@example.io",
+				Active:  true,
+				Address: address{Street: "1 Main St", City: "Springfield"},
+				City:    "Springfield",
+				Tags:    []string{"new", "priority"},
+				Items:   []item{{SKU: "A-1", Qty: 2}, {SKU: "B-2", Qty: 5}},
+			}
+			if !reflect.DeepEqual(p, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, p)
+			}
+		})
+	}
+}
+
+func TestBindXMLSingleRepeatedElement(t *testing.T) {
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(`<list><tag>only</tag></list>`))
+	r.Header.Set("Content-Type", "application/xml")
+
+	type params struct {
+		Tags []string `xml:"tag"`
+	}
+
+	var p params
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if !reflect.DeepEqual(p.Tags, []string{"only"}) {
+		t.Errorf("Expected Tags to be [only], got %v", p.Tags)
+	}
+}
+
+func TestBindXMLNamespaceDeclarations(t *testing.T) {
+	body := `<user xmlns="urn:x" xmlns:a="urn:a" a:id="7"><name xmlns="urn:y">Alice</name></user>`
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/xml")
+
+	type params struct {
+		ID   string `xml:"id,attr"`
+		Name string `xml:"name"`
+	}
+
+	var p params
+	if err := BindWithOptions(r, &p, BindOptions{RejectUnknownFields: true}); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.ID != "7" || p.Name != "Alice" {
+		t.Errorf("Expected {7 Alice}, got %+v", p)
+	}
+}
+
+func TestBindSameStructJSONAndXML(t *testing.T) {
+	type params struct {
+		Name  string `body:"name"`
+		Count int    `body:"count"`
+	}
+
+	requests := map[string]string{
+		"application/json": `{"name": "widget", "count": 3}`,
+		"application/xml":  `<widget><name>widget</name><count>3</count></widget>`,
+	}
+
+	for ct, payload := range requests {
+		r := httptest.NewRequest("POST", "/test", strings.NewReader(payload))
+		r.Header.Set("Content-Type", ct)
+
+		var p params
+		if err := Bind(r, &p); err != nil {
+			t.Fatalf("%s: binding failed with error: %v", ct, err)
+		}
+		if p.Name != "widget" || p.Count != 3 {
+			t.Errorf("%s: unexpected result %+v", ct, p)
+		}
+	}
+}
+
+func TestBindBodyTagOptions(t *testing.T) {
+	r := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name": "Alice", "email": ""}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	type params struct {
+		Name  string `body:"name,omitempty"`
+		Email string `json:"email,omitempty"`
+	}
+
+	p := params{Email: "keep@example.io"}
+	if err := Bind(r, &p); err != nil {
+		t.Fatalf("Binding failed with error: %v", err)
+	}
+	if p.Name != "Alice" {
+		t.Errorf("Expected Name to be Alice, got %q", p.Name)
+	}
+	if p.Email != "keep@example.io" {
+		t.Errorf("Expected Email to be kept, got %q", p.Email)
+	}
+}